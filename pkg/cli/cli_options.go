@@ -25,6 +25,10 @@ type (
 
 	UpdateOptions struct {
 		Stream OptionalString `yaml:",omitempty"`
+		// Mirror is a local directory to read releases from instead of the update server
+		Mirror OptionalString `yaml:",omitempty"`
+		// Verify requires updates to have a signed release manifest
+		Verify OptionalBool `yaml:",omitempty"`
 	}
 
 	UIOptions struct {
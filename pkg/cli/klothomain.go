@@ -71,6 +71,8 @@ var cfg struct {
 	version            bool
 	uploadSource       bool
 	update             bool
+	allowDowngrade     bool
+	verifyUpdate       bool
 	cfgFormat          string
 	setOption          map[string]string
 	login              bool
@@ -136,6 +138,8 @@ func (km KlothoMain) Main() {
 	flags.BoolVar(&cfg.internalDebug, "internalDebug", false, "Enable debugging for compiler")
 	flags.BoolVar(&cfg.version, "version", false, "Print the version")
 	flags.BoolVar(&cfg.update, "update", false, "update the cli to the latest version")
+	flags.BoolVar(&cfg.allowDowngrade, "allow-downgrade", false, "allow --update to install an older version")
+	flags.BoolVar(&cfg.verifyUpdate, "verify-update", false, "require --update to verify the signed release manifest (also the update.verify option)")
 	flags.StringToStringVar(&cfg.setOption, "set-option", nil, "Sets a CLI option")
 	flags.BoolVar(&cfg.login, "login", false, "Login to Klotho with email.")
 	flags.BoolVar(&cfg.logout, "logout", false, "Logout of current klotho account.")
@@ -305,9 +309,12 @@ func (km KlothoMain) run(cmd *cobra.Command, args []string) (err error) {
 
 	// if update is specified do the update in place
 	var klothoUpdater = updater.Updater{
-		ServerURL:     updater.DefaultServer,
-		Stream:        updateStream,
-		CurrentStream: km.DefaultUpdateStream,
+		ServerURL:      updater.DefaultServer,
+		Stream:         updateStream,
+		CurrentStream:  km.DefaultUpdateStream,
+		MirrorDir:      string(options.Update.Mirror),
+		AllowDowngrade: cfg.allowDowngrade,
+		Verify:         cfg.verifyUpdate || options.Update.Verify.OrDefault(false),
 		Client: httpclient.NewClient(
 			httpclient.WithHTTPTimeout(5*time.Minute),
 			httpclient.WithRetryCount(3),
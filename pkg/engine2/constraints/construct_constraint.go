@@ -19,7 +19,8 @@ type (
 	// target: klotho:orm:my_orm
 	// type: rds_instance
 	//
-	// The end result of this should be that the orm construct is expanded into an rds instance + necessary resources
+	// The end result of this should be that the orm construct is expanded into an rds instance + necessary resources.
+	// The type may also be qualified with its provider, eg `aws:rds_cluster`
 	ConstructConstraint struct {
 		Operator   ConstraintOperator   `yaml:"operator"`
 		Target     construct.ResourceId `yaml:"target"`
@@ -41,7 +42,7 @@ func (constraint *ConstructConstraint) IsSatisfied(ctx ConstraintGraph) bool {
 		if res == nil {
 			return false
 		}
-		if constraint.Type != "" && res.ID.Type != constraint.Type {
+		if constraint.Type != "" && res.ID.Type != constraint.Type && res.ID.QualifiedTypeName() != constraint.Type {
 			return false
 		}
 		return true
@@ -3,6 +3,7 @@ package constructexpansion
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/dominikbraun/graph"
 	"github.com/klothoplatform/klotho/pkg/collectionutil"
@@ -43,10 +44,12 @@ func (ctx *ConstructExpansionContext) ExpandConstruct(res *construct.Resource, c
 	attributes := make(map[string]any)
 	for _, constructConstraint := range constraints {
 		if constructConstraint.Target == res.ID {
-			constructType = constructConstraint.Type
-			if constructType != "" && constructType != constructConstraint.Type {
+			if constructType != "" && constructConstraint.Type != "" && constructType != constructConstraint.Type {
 				return nil, fmt.Errorf("unable to expand construct %s, conflicting types in constraints", res.ID)
 			}
+			if constructConstraint.Type != "" {
+				constructType = constructConstraint.Type
+			}
 			for k, v := range constructConstraint.Attributes {
 				if val, ok := attributes[k]; ok {
 					if v != val {
@@ -69,7 +72,7 @@ func (ctx *ConstructExpansionContext) findPossibleExpansions(expansionSet Expans
 	var joinedErr error
 	functionality := knowledgebase.GetFunctionality(ctx.Kb, expansionSet.Construct.ID)
 	for _, res := range ctx.Kb.ListResources() {
-		if constructQualifiedType != "" && res.Id().QualifiedTypeName() != constructQualifiedType {
+		if !matchesConstructType(res.Id(), constructQualifiedType) {
 			continue
 		}
 		classifications := res.Classification
@@ -146,3 +149,16 @@ func (ctx *ConstructExpansionContext) findExpansions(attributes []string, edges
 	}
 	return result, nil
 }
+
+// matchesConstructType returns whether the resource type satisfies the type requested by a construct constraint.
+// The type may be qualified with the provider (eg `aws:rds_cluster`) or not (eg `rds_cluster`), matching how
+// [constraints.ConstructConstraint] compares types once the construct is expanded.
+func matchesConstructType(id construct.ResourceId, constructType string) bool {
+	if constructType == "" {
+		return true
+	}
+	if strings.Contains(constructType, ":") {
+		return id.QualifiedTypeName() == constructType
+	}
+	return id.Type == constructType
+}
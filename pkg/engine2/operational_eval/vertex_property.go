@@ -208,7 +208,10 @@ func (v *propertyVertex) evaluateConstraints(sol solution_context.SolutionContex
 
 	ctx := solution_context.DynamicCtx(sol)
 	var defaultVal any
-	if currentValue == nil && !res.Imported {
+	// Edges append to lists and sets, so the elements they add take the place of the default
+	appendedByEdges := len(v.EdgeRules) > 0 && v.Template != nil &&
+		(strings.HasPrefix(v.Template.Type(), "list") || strings.HasPrefix(v.Template.Type(), "set"))
+	if currentValue == nil && !res.Imported && !appendedByEdges {
 		defaultVal, err = v.Template.GetDefaultValue(ctx, dynData)
 		if err != nil {
 			return fmt.Errorf("could not get default value for %s: %w", v.Ref, err)
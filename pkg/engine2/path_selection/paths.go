@@ -58,8 +58,9 @@ func GetPaths(
 			if expansion.Classification != "" {
 			PATHS:
 				for _, path := range paths {
+					// direct edge only edges can satisfy the edge itself, but not be part of a longer path
 					for i, res := range path {
-						if i == 0 {
+						if i == 0 || len(path) == 2 {
 							continue
 						}
 						if et := sol.KnowledgeBase().GetEdgeTemplate(path[i-1], res); et != nil && et.DirectEdgeOnly {
@@ -0,0 +1,72 @@
+package path_selection
+
+import (
+	"testing"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	"github.com/klothoplatform/klotho/pkg/engine2/enginetesting"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetPaths(t *testing.T) {
+	a := construct.ResourceId{Provider: "p", Type: "a", Name: "a"}
+	b := construct.ResourceId{Provider: "p", Type: "b", Name: "b"}
+	c := construct.ResourceId{Provider: "p", Type: "c", Name: "c"}
+
+	tests := []struct {
+		name           string
+		initialState   []any
+		directEdgeOnly []construct.SimpleEdge
+		want           []construct.Path
+	}{
+		{
+			name:         "all paths",
+			initialState: []any{"p:a:a -> p:b:b", "p:b:b -> p:c:c", "p:a:a -> p:c:c"},
+			want:         []construct.Path{{a, b, c}, {a, c}},
+		},
+		{
+			name:           "direct edge only satisfies its own edge",
+			initialState:   []any{"p:a:a -> p:c:c"},
+			directEdgeOnly: []construct.SimpleEdge{{Source: a, Target: c}},
+			want:           []construct.Path{{a, c}},
+		},
+		{
+			name:           "direct edge only is not used within a path",
+			initialState:   []any{"p:a:a -> p:b:b", "p:b:b -> p:c:c", "p:a:a -> p:c:c"},
+			directEdgeOnly: []construct.SimpleEdge{{Source: a, Target: b}},
+			want:           []construct.Path{{a, c}},
+		},
+		{
+			name:           "no path without direct edge only edges",
+			initialState:   []any{"p:a:a -> p:b:b", "p:b:b -> p:c:c"},
+			directEdgeOnly: []construct.SimpleEdge{{Source: b, Target: c}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sol := enginetesting.NewTestSolution()
+			sol.KB.On("GetPathSatisfactionsFromEdge", a, c).Return(
+				[]knowledgebase.EdgePathSatisfaction{{Classification: "network"}}, nil,
+			)
+			sol.KB.On("GetResourceTemplate", mock.Anything).Return(&knowledgebase.ResourceTemplate{
+				Classification: knowledgebase.Classification{Is: []string{"network"}},
+			}, nil)
+			for _, edge := range tt.directEdgeOnly {
+				sol.KB.On("GetEdgeTemplate", edge.Source, edge.Target).Return(
+					&knowledgebase.EdgeTemplate{DirectEdgeOnly: true},
+				)
+			}
+			sol.KB.On("GetEdgeTemplate", mock.Anything, mock.Anything).Return(&knowledgebase.EdgeTemplate{})
+			sol.LoadState(t, tt.initialState...)
+
+			got, err := GetPaths(sol, a, c, func(source, target construct.ResourceId, path construct.Path) bool {
+				return true
+			}, false)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
@@ -24,6 +24,8 @@ var generateIacCfg struct {
 	verbose    bool
 	jsonLog    bool
 	profileTo  string
+	outputs    []string
+	verify     bool
 }
 
 func (i *IacCli) AddIacCli(root *cobra.Command) error {
@@ -10,6 +10,7 @@ import (
 	"github.com/klothoplatform/klotho/pkg/config"
 	construct "github.com/klothoplatform/klotho/pkg/construct2"
 	engine "github.com/klothoplatform/klotho/pkg/engine2"
+	"github.com/klothoplatform/klotho/pkg/infra/cloudformation"
 	"github.com/klothoplatform/klotho/pkg/infra/iac3"
 	"github.com/klothoplatform/klotho/pkg/infra/kubernetes"
 	"github.com/klothoplatform/klotho/pkg/io"
@@ -29,13 +30,15 @@ func AddIacCli(root *cobra.Command) error {
 		RunE:  GenerateIac,
 	}
 	flags := generateCmd.Flags()
-	flags.StringVarP(&generateIacCfg.provider, "provider", "p", "pulumi", "Provider to use")
+	flags.StringVarP(&generateIacCfg.provider, "provider", "p", "pulumi", "Provider to use (pulumi or cloudformation)")
 	flags.StringVarP(&generateIacCfg.inputGraph, "input-graph", "i", "", "Input graph to use")
 	flags.StringVarP(&generateIacCfg.outputDir, "output-dir", "o", "", "Output directory to use")
 	flags.StringVarP(&generateIacCfg.appName, "app-name", "a", "", "App name to use")
 	flags.BoolVarP(&generateIacCfg.verbose, "verbose", "v", false, "Verbose flag")
 	flags.BoolVar(&generateIacCfg.jsonLog, "json-log", false, "Output logs in JSON format.")
 	flags.StringVar(&generateIacCfg.profileTo, "profiling", "", "Profile to file")
+	flags.StringSliceVar(&generateIacCfg.outputs, "outputs", nil, "Resource exports to include as stack outputs, pulumi only (e.g. aws:load_balancer#DnsName)")
+	flags.BoolVar(&generateIacCfg.verify, "verify", false, "Verify the references in the generated program and templates, pulumi only")
 	root.AddCommand(generateCmd)
 	return nil
 }
@@ -95,6 +98,9 @@ func GenerateIac(cmd *cobra.Command, args []string) error {
 	if generateIacCfg.inputGraph == "" {
 		return fmt.Errorf("input graph required")
 	}
+	if generateIacCfg.provider != "pulumi" && (len(generateIacCfg.outputs) > 0 || generateIacCfg.verify) {
+		return fmt.Errorf("--outputs and --verify are not supported by provider %s", generateIacCfg.provider)
+	}
 	inputF, err := os.Open(generateIacCfg.inputGraph)
 	if err != nil {
 		return fmt.Errorf("failed to open input graph: %w", err)
@@ -127,15 +133,36 @@ func GenerateIac(cmd *cobra.Command, args []string) error {
 	files = append(files, k8sfiles...)
 	switch generateIacCfg.provider {
 	case "pulumi":
+		var outputs []iac3.OutputSelector
+		for _, o := range generateIacCfg.outputs {
+			sel, err := iac3.ParseOutputSelector(o)
+			if err != nil {
+				return err
+			}
+			outputs = append(outputs, sel)
+		}
 		pulumiPlugin := iac3.Plugin{
-			Config: &iac3.PulumiConfig{AppName: generateIacCfg.appName},
-			KB:     kb,
+			Config: &iac3.PulumiConfig{
+				AppName: generateIacCfg.appName,
+				Outputs: outputs,
+				Verify:  generateIacCfg.verify,
+			},
+			KB: kb,
 		}
 		iacFiles, err := pulumiPlugin.Translate(solCtx)
 		if err != nil {
 			return err
 		}
 		files = append(files, iacFiles...)
+	case "cloudformation":
+		cfnPlugin := cloudformation.Plugin{
+			Config: &cloudformation.Config{AppName: generateIacCfg.appName},
+		}
+		cfnFiles, err := cfnPlugin.Translate(solCtx)
+		if err != nil {
+			return err
+		}
+		files = append(files, cfnFiles...)
 	default:
 		return fmt.Errorf("provider %s not supported", generateIacCfg.provider)
 	}
@@ -0,0 +1,1827 @@
+package cloudformation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+)
+
+type (
+	// resourceMapping describes how a knowledge base resource is rendered as a CloudFormation resource.
+	resourceMapping struct {
+		// Type is the CloudFormation resource type, eg `AWS::Lambda::Function`
+		Type string
+		// TypeProperty is the knowledge base property whose value chooses the CloudFormation type from Types, for
+		// resources which are one of several CloudFormation types. Values not in Types use Type.
+		TypeProperty string
+		Types        map[string]string
+		// NameProperty is the CloudFormation property which is set to the resource's name, if any
+		NameProperty string
+		// NamePaths are additional paths (see [propertyMapping.Path]) which are set to the resource's name
+		NamePaths []string
+		// Static are CloudFormation properties which are always set
+		Static map[string]any
+		// Properties maps knowledge base properties to CloudFormation properties. Properties which are not in the
+		// mapping are not rendered.
+		Properties map[string]propertyMapping
+		// Attributes maps the properties of the resource that other resources can reference
+		// (via [construct.PropertyRef]) to the attribute used by `Fn::GetAtt`. An empty attribute uses `Ref`.
+		// Attributes containing `${Attr}` placeholders are rendered with `Fn::Sub`, for values derived from an attribute.
+		Attributes map[string]string
+		// DerivedAttributes are attributes (see [resourceMapping.Attributes]) which also depend on the values of the
+		// resource's properties.
+		DerivedAttributes map[string]func(r *construct.Resource) (string, error)
+		// Parameter is set for resources which CloudFormation does not create. Instead, their `Ref` value is supplied
+		// as a template parameter.
+		Parameter bool
+		// ParameterType is the type of the parameter when the resource is imported or is a [resourceMapping.Parameter].
+		// Defaults to `String`.
+		ParameterType string
+		// SecretParameters maps the paths of CloudFormation properties, such as credentials, to `NoEcho` template
+		// parameters which supply their value, unless the resource's properties already set it. Each parameter is named
+		// after the logical ID of the resource suffixed by the name it is mapped to, which other resources can
+		// reference like an attribute.
+		SecretParameters map[string]string
+		// Adjust modifies the rendered properties, for CloudFormation properties which depend on more than one
+		// knowledge base property.
+		Adjust func(props map[string]any)
+		// Embed renders the resource as part of another resource, for knowledge base resources which are not their
+		// own CloudFormation resource. Embedded resources can only be referenced by attributes rendered with `Fn::Sub`.
+		Embed *embedMapping
+	}
+
+	propertyMapping struct {
+		// Path is the dot-separated path of the property in the CloudFormation resource. A path ending in `[]`
+		// appends the value to the list at that path, and a part ending in `[N]` refers to the N-th list element.
+		// An empty path merges the fields of the (object) value into the resource's properties.
+		Path string
+		// Attribute is the attribute used when the property references another resource. Empty uses `Ref`.
+		Attribute string
+		// AttributeFields renders a reference to another resource as an object instead, whose fields are set to the
+		// given properties of the referenced resource (as if they were [construct.PropertyRef]s).
+		AttributeFields map[string]string
+		// Fields renames the keys of object values (or each object in a list value). A dot-separated key renames
+		// a key of the nested object, eg `Config.Port` renames `Port` within `Config` (which may itself be renamed).
+		Fields map[string]string
+		// Convert transforms the value after it has been rendered and its fields renamed.
+		Convert func(v any) (any, error)
+		// Child renders each element of the value as its own resource instead of as a property.
+		Child *childMapping
+	}
+
+	embedMapping struct {
+		// Owner is the knowledge base property which references the resource this resource is part of
+		Owner string
+		// Path is the path (see [propertyMapping.Path]) the resource's properties are set to within the owner. An empty
+		// path merges them into the owner's properties.
+		Path string
+		// Property, if set, is the only one of the resource's (CloudFormation) properties which is set at Path, for
+		// resources which are a single value within the owner.
+		Property string
+	}
+
+	childMapping struct {
+		// Type is the CloudFormation resource type of each child
+		Type string
+		// Parent is the property of the child which references the resource that owns the property
+		Parent string
+	}
+
+	// selfRef is replaced by a `Ref` to the resource which owns the property. It is only valid within child resources,
+	// since a resource referencing itself is a circular dependency.
+	selfRef struct{}
+)
+
+// pseudoResources are resources which do not correspond to a CloudFormation resource. Any reference to them
+// is rendered as the value returned.
+var pseudoResources = map[string]func(props map[string]any) (any, error){
+	"aws:region": func(map[string]any) (any, error) {
+		return ref("AWS::Region"), nil
+	},
+	"aws:account_id": func(map[string]any) (any, error) {
+		return ref("AWS::AccountId"), nil
+	},
+	"aws:availability_zone": func(props map[string]any) (any, error) {
+		var index int
+		switch i := props["Index"].(type) {
+		case int:
+			index = i
+		case float64:
+			index = int(i)
+		default:
+			return nil, fmt.Errorf("availability zone has invalid index %v (%[1]T)", i)
+		}
+		return map[string]any{
+			"Fn::Select": []any{index, map[string]any{"Fn::GetAZs": ""}},
+		}, nil
+	},
+}
+
+// listenerActionFields renames the fields of the load balancer listener action model to the CloudFormation action
+var listenerActionFields = map[string]string{
+	"AuthenticateCognito":                "AuthenticateCognitoConfig",
+	"AuthenticateCognito.UserPool":       "UserPoolArn",
+	"AuthenticateCognito.UserPoolClient": "UserPoolClientId",
+	"FixedResponse":                      "FixedResponseConfig",
+	"Redirect":                           "RedirectConfig",
+	"TargetGroup":                        "TargetGroupArn",
+}
+
+var resourceMappings = map[string]resourceMapping{
+	"aws:acm_certificate": {
+		Type: "AWS::CertificateManager::Certificate",
+		Properties: map[string]propertyMapping{
+			"CertificateTransparencyLoggingPreference": {Path: "CertificateTransparencyLoggingPreference"},
+			"DomainName":              {Path: "DomainName"},
+			"DomainValidationOptions": {Path: "DomainValidationOptions"},
+			"SubjectAlternativeNames": {Path: "SubjectAlternativeNames"},
+			"Tags":                    {Path: "Tags", Convert: tagList},
+			"ValidationMethod":        {Path: "ValidationMethod"},
+		},
+		Attributes: map[string]string{
+			"Arn": "",
+		},
+	},
+	// Certificates are only created once they are issued, so validating them needs no resource of its own
+	"aws:acm_certificate_validation": {
+		Embed: &embedMapping{Owner: "Certificate"},
+		Attributes: map[string]string{
+			"CertificateArn": "${Certificate}",
+		},
+	},
+	"aws:ami": {
+		Parameter:     true,
+		ParameterType: "AWS::EC2::Image::Id",
+	},
+	"aws:api_authorizer": {
+		Type:         "AWS::ApiGateway::Authorizer",
+		NameProperty: "Name",
+		Properties: map[string]propertyMapping{
+			"AuthorizerFunction":           {Path: "AuthorizerUri", Attribute: "LambdaIntegrationUri"},
+			"AuthorizerResultTtlInSeconds": {Path: "AuthorizerResultTtlInSeconds"},
+			"IdentitySource":               {Path: "IdentitySource"},
+			"RestApi":                      {Path: "RestApiId"},
+			"Type":                         {Path: "Type"},
+			"UserPools":                    {Path: "ProviderARNs", Attribute: "Arn"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	"aws:api_deployment": {
+		Type: "AWS::ApiGateway::Deployment",
+		Properties: map[string]propertyMapping{
+			"RestApi": {Path: "RestApiId"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	// Integrations are part of their method in CloudFormation
+	"aws:api_integration": {
+		Embed: &embedMapping{Owner: "Method", Path: "Integration"},
+		Properties: map[string]propertyMapping{
+			"ConnectionType":        {Path: "ConnectionType"},
+			"Credentials":           {Path: "Credentials", Attribute: "Arn"},
+			"IntegrationHttpMethod": {Path: "IntegrationHttpMethod"},
+			"RequestParameters":     {Path: "RequestParameters"},
+			"RequestTemplates":      {Path: "RequestTemplates"},
+			"Type":                  {Path: "Type"},
+			"Uri":                   {Path: "Uri"},
+			"VpcLink":               {Path: "ConnectionId"},
+		},
+		DerivedAttributes: map[string]func(r *construct.Resource) (string, error){
+			"LbUri": integrationLbUri,
+		},
+	},
+	"aws:api_method": {
+		Type: "AWS::ApiGateway::Method",
+		Properties: map[string]propertyMapping{
+			"Authorization":     {Path: "AuthorizationType"},
+			"Authorizer":        {Path: "AuthorizerId"},
+			"HttpMethod":        {Path: "HttpMethod"},
+			"RequestParameters": {Path: "RequestParameters"},
+			"Resource":          {Path: "ResourceId"},
+			"RestApi":           {Path: "RestApiId"},
+		},
+		Adjust: rootResourceDefault("ResourceId"),
+	},
+	"aws:api_resource": {
+		Type: "AWS::ApiGateway::Resource",
+		Properties: map[string]propertyMapping{
+			"ParentResource": {Path: "ParentId"},
+			"PathPart":       {Path: "PathPart"},
+			"RestApi":        {Path: "RestApiId"},
+		},
+		Adjust: rootResourceDefault("ParentId"),
+	},
+	"aws:api_stage": {
+		Type: "AWS::ApiGateway::Stage",
+		Properties: map[string]propertyMapping{
+			"Deployment": {Path: "DeploymentId"},
+			"RestApi":    {Path: "RestApiId"},
+			"StageName":  {Path: "StageName"},
+		},
+		Attributes: map[string]string{
+			"Arn":            "arn:${AWS::Partition}:apigateway:${AWS::Region}::/restapis/${RestApi}/stages/${StageName}",
+			"InvokeUrl":      "https://${RestApi}.execute-api.${AWS::Region}.${AWS::URLSuffix}/${StageName}",
+			"StageInvokeUrl": "${RestApi}.execute-api.${AWS::Region}.${AWS::URLSuffix}",
+			"StageName":      "",
+		},
+	},
+	"aws:app_runner_service": {
+		Type:         "AWS::AppRunner::Service",
+		NameProperty: "ServiceName",
+		Static: map[string]any{
+			"SourceConfiguration": map[string]any{
+				"ImageRepository": map[string]any{"ImageRepositoryType": "ECR"},
+			},
+			"NetworkConfiguration": map[string]any{
+				"EgressConfiguration":  map[string]any{"EgressType": "DEFAULT"},
+				"IngressConfiguration": map[string]any{"IsPubliclyAccessible": true},
+			},
+		},
+		Properties: map[string]propertyMapping{
+			"EnvironmentVariables": {
+				Path:    "SourceConfiguration.ImageRepository.ImageConfiguration.RuntimeEnvironmentVariables",
+				Convert: nameValueList,
+			},
+			"Image":        {Path: "SourceConfiguration.ImageRepository.ImageIdentifier"},
+			"InstanceRole": {Path: "InstanceConfiguration.InstanceRoleArn", Attribute: "Arn"},
+			"Port":         {Path: "SourceConfiguration.ImageRepository.ImageConfiguration.Port", Convert: stringValues},
+		},
+		Attributes: map[string]string{
+			"Arn": "ServiceArn",
+			"Url": "ServiceUrl",
+		},
+		Adjust: func(props map[string]any) {
+			// The instance role also pulls the image
+			if role, ok := props["InstanceConfiguration"].(map[string]any)["InstanceRoleArn"]; ok {
+				setPath(props, "SourceConfiguration.AuthenticationConfiguration.AccessRoleArn", role)
+			}
+		},
+	},
+	"aws:cloudfront_distribution": {
+		Type: "AWS::CloudFront::Distribution",
+		Properties: map[string]propertyMapping{
+			"Aliases":                      {Path: "DistributionConfig.Aliases"},
+			"Certificate":                  {Path: "DistributionConfig.ViewerCertificate.AcmCertificateArn"},
+			"CloudfrontDefaultCertificate": {Path: "DistributionConfig.ViewerCertificate.CloudFrontDefaultCertificate"},
+			"DefaultCacheBehavior": {
+				Path:   "DistributionConfig.DefaultCacheBehavior",
+				Fields: map[string]string{"DefaultTtl": "DefaultTTL", "MaxTtl": "MaxTTL", "MinTtl": "MinTTL"},
+			},
+			"DefaultRootObject": {Path: "DistributionConfig.DefaultRootObject"},
+			"Enabled":           {Path: "DistributionConfig.Enabled"},
+			"Origins": {
+				Path: "DistributionConfig.Origins",
+				Fields: map[string]string{
+					"OriginId":                              "Id",
+					"CustomOriginConfig.HttpPort":           "HTTPPort",
+					"CustomOriginConfig.HttpsPort":          "HTTPSPort",
+					"CustomOriginConfig.OriginSslProtocols": "OriginSSLProtocols",
+				},
+			},
+			"Restrictions": {Path: "DistributionConfig.Restrictions"},
+			"WebAclId":     {Path: "DistributionConfig.WebACLId"},
+		},
+		Attributes: map[string]string{
+			"DomainName": "DomainName",
+		},
+		Adjust: func(props map[string]any) {
+			// A distribution with its own certificate cannot also use the default one
+			config, _ := props["DistributionConfig"].(map[string]any)
+			viewer, _ := config["ViewerCertificate"].(map[string]any)
+			if _, ok := viewer["AcmCertificateArn"]; ok {
+				delete(viewer, "CloudFrontDefaultCertificate")
+				viewer["SslSupportMethod"] = "sni-only"
+			}
+		},
+	},
+	"aws:cloudfront_origin_access_identity": {
+		Type: "AWS::CloudFront::CloudFrontOriginAccessIdentity",
+		// The comment is required
+		Static: map[string]any{"CloudFrontOriginAccessIdentityConfig": map[string]any{"Comment": "Managed by Klotho"}},
+		Properties: map[string]propertyMapping{
+			"Comment": {Path: "CloudFrontOriginAccessIdentityConfig.Comment"},
+		},
+		Attributes: map[string]string{
+			"CloudfrontAccessIdentityPath": "origin-access-identity/cloudfront/${Id}",
+			"IamArn":                       "arn:${AWS::Partition}:iam::cloudfront:user/CloudFront Origin Access Identity ${Id}",
+			"Id":                           "",
+		},
+	},
+	"aws:cloudwatch_alarm": {
+		Type:         "AWS::CloudWatch::Alarm",
+		NameProperty: "AlarmName",
+		Properties: map[string]propertyMapping{
+			"ActionsEnabled":     {Path: "ActionsEnabled"},
+			"AlarmActions":       {Path: "AlarmActions"},
+			"AlarmDescription":   {Path: "AlarmDescription"},
+			"ComparisonOperator": {Path: "ComparisonOperator"},
+			"DatapointsToAlarm":  {Path: "DatapointsToAlarm"},
+			"Dimensions":         {Path: "Dimensions", Convert: nameValueList},
+			"EvaluationPeriods":  {Path: "EvaluationPeriods"},
+			"MetricName":         {Path: "MetricName"},
+			"Namespace":          {Path: "Namespace"},
+			"OKActions":          {Path: "OKActions"},
+			"Period":             {Path: "Period"},
+			"Statistic":          {Path: "Statistic"},
+			"Tags":               {Path: "Tags", Convert: tagList},
+			"Threshold":          {Path: "Threshold"},
+			"TreatMissingData":   {Path: "TreatMissingData"},
+		},
+		Attributes: map[string]string{
+			"Arn": "Arn",
+		},
+	},
+	"aws:cloudwatch_dashboard": {
+		Type:         "AWS::CloudWatch::Dashboard",
+		NameProperty: "DashboardName",
+		Static:       map[string]any{"DashboardBody": `{"widgets":[]}`},
+		Properties: map[string]propertyMapping{
+			"Widgets": {Path: "DashboardBody", Attribute: "Arn", Convert: dashboardBody},
+		},
+	},
+	"aws:cognito_user_pool": {
+		Type:         "AWS::Cognito::UserPool",
+		NameProperty: "UserPoolName",
+		Properties: map[string]propertyMapping{
+			"AutoVerifiedAttributes": {Path: "AutoVerifiedAttributes"},
+			"MfaConfiguration":       {Path: "MfaConfiguration"},
+			"PasswordPolicy":         {Path: "Policies.PasswordPolicy"},
+			"Tags":                   {Path: "UserPoolTags"},
+			"UsernameAttributes":     {Path: "UsernameAttributes"},
+		},
+		Attributes: map[string]string{
+			"Arn": "Arn",
+			"Id":  "",
+		},
+	},
+	"aws:cognito_user_pool_client": {
+		Type:         "AWS::Cognito::UserPoolClient",
+		NameProperty: "ClientName",
+		Properties: map[string]propertyMapping{
+			"AllowedOAuthFlows":               {Path: "AllowedOAuthFlows"},
+			"AllowedOAuthFlowsUserPoolClient": {Path: "AllowedOAuthFlowsUserPoolClient"},
+			"AllowedOAuthScopes":              {Path: "AllowedOAuthScopes"},
+			"CallbackURLs":                    {Path: "CallbackURLs"},
+			"ExplicitAuthFlows":               {Path: "ExplicitAuthFlows"},
+			"GenerateSecret":                  {Path: "GenerateSecret"},
+			"SupportedIdentityProviders":      {Path: "SupportedIdentityProviders"},
+			"UserPool":                        {Path: "UserPoolId"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	"aws:cognito_user_pool_domain": {
+		Type: "AWS::Cognito::UserPoolDomain",
+		Properties: map[string]propertyMapping{
+			"Domain":   {Path: "Domain"},
+			"UserPool": {Path: "UserPoolId"},
+		},
+	},
+	"aws:dynamodb_table": {
+		Type:         "AWS::DynamoDB::Table",
+		NameProperty: "TableName",
+		Properties: map[string]propertyMapping{
+			"Attributes": {
+				Path:   "AttributeDefinitions",
+				Fields: map[string]string{"Name": "AttributeName", "Type": "AttributeType"},
+			},
+			"BillingMode": {Path: "BillingMode"},
+			// Properties are rendered in sorted order, so the HASH key always precedes the RANGE key
+			"HashKey":             {Path: "KeySchema[]", Convert: keySchema("HASH")},
+			"RangeKey":            {Path: "KeySchema[]", Convert: keySchema("RANGE")},
+			"StreamSpecification": {Path: "StreamSpecification", Convert: streamSpecification},
+		},
+		Attributes: map[string]string{
+			"Arn":                  "Arn",
+			"Name":                 "",
+			"DynamoTableBackupArn": "${Arn}/backup/*",
+			"DynamoTableExportArn": "${Arn}/export/*",
+			"DynamoTableIndexArn":  "${Arn}/index/*",
+			"DynamoTableStreamArn": "StreamArn",
+			"StreamArn":            "StreamArn",
+		},
+	},
+	"aws:ec2_instance": {
+		Type: "AWS::EC2::Instance",
+		Properties: map[string]propertyMapping{
+			"AMI":             {Path: "ImageId"},
+			"InstanceProfile": {Path: "IamInstanceProfile"},
+			"InstanceType":    {Path: "InstanceType"},
+			"SecurityGroup":   {Path: "SecurityGroupIds"},
+			"Subnet":          {Path: "SubnetId"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	"aws:ec2_transit_gateway": {
+		Type: "AWS::EC2::TransitGateway",
+		Properties: map[string]propertyMapping{
+			"AmazonSideAsn":                {Path: "AmazonSideAsn"},
+			"AutoAcceptSharedAttachments":  {Path: "AutoAcceptSharedAttachments"},
+			"DefaultRouteTableAssociation": {Path: "DefaultRouteTableAssociation"},
+			"DefaultRouteTablePropagation": {Path: "DefaultRouteTablePropagation"},
+			"Description":                  {Path: "Description"},
+			"DnsSupport":                   {Path: "DnsSupport"},
+			"Tags":                         {Path: "Tags", Convert: tagList},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	"aws:ec2_transit_gateway_route_table_propagation": {
+		Type: "AWS::EC2::TransitGatewayRouteTablePropagation",
+		Properties: map[string]propertyMapping{
+			"Attachment":                 {Path: "TransitGatewayAttachmentId"},
+			"TransitGatewayRouteTableId": {Path: "TransitGatewayRouteTableId"},
+		},
+	},
+	"aws:ec2_transit_gateway_vpc_attachment": {
+		Type: "AWS::EC2::TransitGatewayVpcAttachment",
+		Properties: map[string]propertyMapping{
+			"ApplianceModeSupport": {Path: "Options.ApplianceModeSupport"},
+			"DnsSupport":           {Path: "Options.DnsSupport"},
+			"Subnets":              {Path: "SubnetIds"},
+			"Tags":                 {Path: "Tags", Convert: tagList},
+			"TransitGateway":       {Path: "TransitGatewayId"},
+			"Vpc":                  {Path: "VpcId"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	"aws:ecr_image": {
+		Parameter: true,
+		Attributes: map[string]string{
+			"ImageName": "",
+		},
+	},
+	"aws:ecr_repo": {
+		Type: "AWS::ECR::Repository",
+		Properties: map[string]propertyMapping{
+			"ForceDelete": {Path: "EmptyOnDelete"},
+		},
+	},
+	"aws:ecs_cluster": {
+		Type:         "AWS::ECS::Cluster",
+		NameProperty: "ClusterName",
+		Properties: map[string]propertyMapping{
+			"ContainerInsights": {Path: "ClusterSettings", Convert: containerInsights},
+		},
+		Attributes: map[string]string{
+			"Arn": "Arn",
+		},
+	},
+	"aws:ecs_service": {
+		Type:         "AWS::ECS::Service",
+		NameProperty: "ServiceName",
+		Properties: map[string]propertyMapping{
+			"AssignPublicIp": {
+				Path:    "NetworkConfiguration.AwsvpcConfiguration.AssignPublicIp",
+				Convert: enabledFlag,
+			},
+			"CapacityProviderStrategy": {Path: "CapacityProviderStrategy"},
+			"Cluster":                  {Path: "Cluster"},
+			"DeploymentCircuitBreaker": {Path: "DeploymentConfiguration.DeploymentCircuitBreaker"},
+			"DesiredCount":             {Path: "DesiredCount"},
+			"LaunchType":               {Path: "LaunchType"},
+			"LoadBalancers": {
+				Path:   "LoadBalancers",
+				Fields: map[string]string{"TargetGroup": "TargetGroupArn"},
+			},
+			"SecurityGroups": {Path: "NetworkConfiguration.AwsvpcConfiguration.SecurityGroups"},
+			"Subnets":        {Path: "NetworkConfiguration.AwsvpcConfiguration.Subnets"},
+			"TaskDefinition": {Path: "TaskDefinition"},
+		},
+		Attributes: map[string]string{
+			"Name":              "Name",
+			"ScalingResourceId": "service/${Cluster}/${Name}",
+		},
+		Adjust: func(props map[string]any) {
+			// A service's capacity provider strategy replaces its launch type
+			if _, ok := props["CapacityProviderStrategy"]; ok {
+				delete(props, "LaunchType")
+			}
+		},
+	},
+	"aws:ecs_task_definition": {
+		Type:         "AWS::ECS::TaskDefinition",
+		NameProperty: "Family",
+		// The task definition has a single container, named after the task definition
+		NamePaths: []string{
+			"ContainerDefinitions[0].Name",
+			"ContainerDefinitions[0].LogConfiguration.Options.awslogs-stream-prefix",
+		},
+		Static: map[string]any{
+			"ContainerDefinitions": []any{map[string]any{
+				"LogConfiguration": map[string]any{"LogDriver": "awslogs"},
+			}},
+		},
+		Properties: map[string]propertyMapping{
+			"Cpu":                     {Path: "Cpu"},
+			"EnvironmentVariables":    {Path: "ContainerDefinitions[0].Environment", Convert: nameValueList},
+			"ExecutionRole":           {Path: "ExecutionRoleArn", Attribute: "Arn"},
+			"Image":                   {Path: "ContainerDefinitions[0].Image"},
+			"LogGroup":                {Path: "ContainerDefinitions[0].LogConfiguration.Options.awslogs-group"},
+			"Memory":                  {Path: "Memory"},
+			"MountPoints":             {Path: "ContainerDefinitions[0].MountPoints"},
+			"NetworkMode":             {Path: "NetworkMode"},
+			"PortMappings":            {Path: "ContainerDefinitions[0].PortMappings", Convert: portMappings},
+			"Region":                  {Path: "ContainerDefinitions[0].LogConfiguration.Options.awslogs-region"},
+			"RequiresCompatibilities": {Path: "RequiresCompatibilities"},
+			"TaskRole":                {Path: "TaskRoleArn", Attribute: "Arn"},
+		},
+		Attributes: map[string]string{
+			"Arn": "",
+		},
+	},
+	"aws:efs_access_point": {
+		Type: "AWS::EFS::AccessPoint",
+		Properties: map[string]propertyMapping{
+			"FileSystem": {Path: "FileSystemId"},
+			// CloudFormation takes the POSIX IDs and permissions as strings
+			"PosixUser":     {Path: "PosixUser", Convert: stringValues},
+			"RootDirectory": {Path: "RootDirectory", Convert: stringValues},
+		},
+		Attributes: map[string]string{
+			"Arn": "Arn",
+			"Id":  "",
+		},
+	},
+	"aws:efs_file_system": {
+		Type: "AWS::EFS::FileSystem",
+		Properties: map[string]propertyMapping{
+			"Encrypted":                    {Path: "Encrypted"},
+			"KmsKey":                       {Path: "KmsKeyId", Attribute: "Arn"},
+			"LifecyclePolicies":            {Path: "LifecyclePolicies", Convert: lifecyclePolicies},
+			"PerformanceMode":              {Path: "PerformanceMode"},
+			"ProvisionedThroughputInMibps": {Path: "ProvisionedThroughputInMibps"},
+			"ThroughputMode":               {Path: "ThroughputMode"},
+		},
+		Attributes: map[string]string{
+			"Arn": "Arn",
+			"Id":  "",
+		},
+	},
+	"aws:efs_mount_target": {
+		Type: "AWS::EFS::MountTarget",
+		Properties: map[string]propertyMapping{
+			"FileSystem":     {Path: "FileSystemId"},
+			"IpAddress":      {Path: "IpAddress"},
+			"SecurityGroups": {Path: "SecurityGroups"},
+			"Subnet":         {Path: "SubnetId"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	"aws:eks_add_on": {
+		Type: "AWS::EKS::Addon",
+		Properties: map[string]propertyMapping{
+			"AddOnName": {Path: "AddonName"},
+			"Cluster":   {Path: "ClusterName"},
+			"Role":      {Path: "ServiceAccountRoleArn", Attribute: "Arn"},
+		},
+	},
+	"aws:eks_cluster": {
+		Type:         "AWS::EKS::Cluster",
+		NameProperty: "Name",
+		Properties: map[string]propertyMapping{
+			"ClusterRole":    {Path: "RoleArn", Attribute: "Arn"},
+			"SecurityGroups": {Path: "ResourcesVpcConfig.SecurityGroupIds"},
+			"Subnets":        {Path: "ResourcesVpcConfig.SubnetIds"},
+			"Version":        {Path: "Version"},
+		},
+		Attributes: map[string]string{
+			"Arn":                      "Arn",
+			"CertificateAuthorityData": "CertificateAuthorityData",
+			"ClusterEndpoint":          "Endpoint",
+			"ClusterSecurityGroup":     "ClusterSecurityGroupId",
+			"Name":                     "",
+		},
+	},
+	"aws:eks_fargate_profile": {
+		Type:         "AWS::EKS::FargateProfile",
+		NameProperty: "FargateProfileName",
+		Properties: map[string]propertyMapping{
+			"Cluster":          {Path: "ClusterName"},
+			"PodExecutionRole": {Path: "PodExecutionRoleArn", Attribute: "Arn"},
+			"Selectors":        {Path: "Selectors", Convert: fargateSelectors},
+			"Subnets":          {Path: "Subnets"},
+		},
+	},
+	"aws:eks_node_group": {
+		Type:         "AWS::EKS::Nodegroup",
+		NameProperty: "NodegroupName",
+		Properties: map[string]propertyMapping{
+			"AmiType":        {Path: "AmiType"},
+			"Cluster":        {Path: "ClusterName"},
+			"DesiredSize":    {Path: "ScalingConfig.DesiredSize"},
+			"DiskSize":       {Path: "DiskSize"},
+			"InstanceTypes":  {Path: "InstanceTypes"},
+			"Labels":         {Path: "Labels"},
+			"MaxSize":        {Path: "ScalingConfig.MaxSize"},
+			"MaxUnavailable": {Path: "UpdateConfig.MaxUnavailable"},
+			"MinSize":        {Path: "ScalingConfig.MinSize"},
+			"NodeRole":       {Path: "NodeRole", Attribute: "Arn"},
+			"Subnets":        {Path: "Subnets"},
+		},
+	},
+	"aws:elastic_ip": {
+		Type:   "AWS::EC2::EIP",
+		Static: map[string]any{"Domain": "vpc"},
+	},
+	"aws:event_bus": {
+		Type:         "AWS::Events::EventBus",
+		NameProperty: "Name",
+		Properties: map[string]propertyMapping{
+			"Tags": {Path: "Tags", Convert: tagList},
+		},
+		Attributes: map[string]string{
+			"Arn":  "Arn",
+			"Name": "",
+		},
+	},
+	"aws:event_rule": {
+		Type:         "AWS::Events::Rule",
+		NameProperty: "Name",
+		Properties: map[string]propertyMapping{
+			"Description":        {Path: "Description"},
+			"EventBus":           {Path: "EventBusName"},
+			"EventPattern":       {Path: "EventPattern", Convert: jsonObject},
+			"ScheduleExpression": {Path: "ScheduleExpression"},
+			"State":              {Path: "State"},
+		},
+		Attributes: map[string]string{
+			"Arn": "Arn",
+		},
+	},
+	// Targets are part of their rule in CloudFormation
+	"aws:event_target": {
+		Embed:        &embedMapping{Owner: "Rule", Path: "Targets[]"},
+		NameProperty: "Id",
+		Properties: map[string]propertyMapping{
+			"Arn": {Path: "Arn"},
+			"AssignPublicIp": {
+				Path:    "EcsParameters.NetworkConfiguration.AwsVpcConfiguration.AssignPublicIp",
+				Convert: enabledFlag,
+			},
+			"Cluster":        {Path: "Arn", Attribute: "Arn"},
+			"Input":          {Path: "Input"},
+			"LaunchType":     {Path: "EcsParameters.LaunchType"},
+			"Role":           {Path: "RoleArn", Attribute: "Arn"},
+			"SecurityGroups": {Path: "EcsParameters.NetworkConfiguration.AwsVpcConfiguration.SecurityGroups"},
+			"Subnets":        {Path: "EcsParameters.NetworkConfiguration.AwsVpcConfiguration.Subnets"},
+			"TaskCount":      {Path: "EcsParameters.TaskCount"},
+			"TaskDefinition": {Path: "EcsParameters.TaskDefinitionArn"},
+		},
+	},
+	"aws:elasticache_cluster": {
+		Type: "AWS::ElastiCache::CacheCluster",
+		Properties: map[string]propertyMapping{
+			"CloudwatchGroup": {Path: "LogDeliveryConfigurations", Convert: cacheLogDelivery},
+			"Engine":          {Path: "Engine"},
+			"NodeType":        {Path: "CacheNodeType"},
+			"NumCacheNodes":   {Path: "NumCacheNodes"},
+			"SecurityGroups":  {Path: "VpcSecurityGroupIds"},
+			"SubnetGroup":     {Path: "CacheSubnetGroupName"},
+		},
+		Attributes: map[string]string{
+			"CacheNodeAddress": "RedisEndpoint.Address",
+			"ClusterAddress":   "ConfigurationEndpoint.Address",
+			"Port":             "RedisEndpoint.Port",
+		},
+	},
+	"aws:elasticache_subnet_group": {
+		Type:   "AWS::ElastiCache::SubnetGroup",
+		Static: map[string]any{"Description": "Managed by Klotho"},
+		Properties: map[string]propertyMapping{
+			"Subnets": {Path: "SubnetIds"},
+		},
+		Attributes: map[string]string{
+			"Name": "",
+		},
+	},
+	"aws:iam_instance_profile": {
+		Type: "AWS::IAM::InstanceProfile",
+		Properties: map[string]propertyMapping{
+			"Role": {Path: "Roles[]"},
+		},
+		Attributes: map[string]string{
+			"Arn":  "Arn",
+			"Name": "",
+		},
+	},
+	"aws:iam_oidc_provider": {
+		Type:   "AWS::IAM::OIDCProvider",
+		Static: map[string]any{"ClientIdList": []any{"sts.amazonaws.com"}},
+		Properties: map[string]propertyMapping{
+			"Cluster": {Path: "Url", Attribute: "OpenIdConnectIssuerUrl"},
+		},
+		Attributes: map[string]string{
+			"Arn": "",
+		},
+	},
+	"aws:iam_policy": {
+		Type:         "AWS::IAM::ManagedPolicy",
+		NameProperty: "ManagedPolicyName",
+		Properties: map[string]propertyMapping{
+			"Policy": {Path: "PolicyDocument"},
+		},
+		Attributes: map[string]string{
+			"Arn": "",
+		},
+	},
+	"aws:iam_role": {
+		Type: "AWS::IAM::Role",
+		Properties: map[string]propertyMapping{
+			"AssumeRolePolicyDoc": {Path: "AssumeRolePolicyDocument"},
+			"InlinePolicies": {
+				Path:   "Policies",
+				Fields: map[string]string{"Name": "PolicyName", "Policy": "PolicyDocument"},
+			},
+			"ManagedPolicies": {Path: "ManagedPolicyArns"},
+		},
+		Attributes: map[string]string{
+			"Arn": "Arn",
+		},
+	},
+	// Attachments are part of their role in CloudFormation
+	"aws:iam_role_policy_attachment": {
+		Embed: &embedMapping{Owner: "Role", Path: "ManagedPolicyArns[]", Property: "PolicyArn"},
+		Properties: map[string]propertyMapping{
+			"Policy": {Path: "PolicyArn"},
+		},
+	},
+	"aws:internet_gateway": {
+		Type: "AWS::EC2::InternetGateway",
+		Properties: map[string]propertyMapping{
+			"Vpc": {
+				Convert: func(v any) (any, error) { return map[string]any{"VpcId": v}, nil },
+				Child:   &childMapping{Type: "AWS::EC2::VPCGatewayAttachment", Parent: "InternetGatewayId"},
+			},
+		},
+	},
+	"aws:kinesis_stream": {
+		Type:         "AWS::Kinesis::Stream",
+		NameProperty: "Name",
+		Properties: map[string]propertyMapping{
+			"RetentionPeriodHours": {Path: "RetentionPeriodHours"},
+			"ShardCount":           {Path: "ShardCount"},
+			"StreamEncryption":     {Path: "StreamEncryption"},
+			"StreamModeDetails":    {Path: "StreamModeDetails"},
+			"Tags":                 {Path: "Tags", Convert: tagList},
+		},
+		Attributes: map[string]string{
+			"Arn": "Arn",
+		},
+	},
+	"aws:kms_alias": {
+		Type: "AWS::KMS::Alias",
+		Properties: map[string]propertyMapping{
+			"AliasName": {Path: "AliasName"},
+			"TargetKey": {Path: "TargetKeyId"},
+		},
+	},
+	"aws:kms_key": {
+		Type: "AWS::KMS::Key",
+		Properties: map[string]propertyMapping{
+			"Description":         {Path: "Description"},
+			"EnableKeyRotation":   {Path: "EnableKeyRotation"},
+			"KeyPolicy":           {Path: "KeyPolicy", Convert: keyPolicy},
+			"KeySpec":             {Path: "KeySpec"},
+			"KeyUsage":            {Path: "KeyUsage"},
+			"MultiRegion":         {Path: "MultiRegion"},
+			"PendingWindowInDays": {Path: "PendingWindowInDays"},
+			"ServicePrincipals":   {Path: "KeyPolicy.Statement[]", Convert: serviceKeyStatement},
+			"Tags":                {Path: "Tags", Convert: tagList},
+		},
+		Attributes: map[string]string{
+			"Arn":   "Arn",
+			"KeyId": "",
+		},
+	},
+	"aws:lambda_event_source_mapping": {
+		Type: "AWS::Lambda::EventSourceMapping",
+		Properties: map[string]propertyMapping{
+			"BatchSize":      {Path: "BatchSize"},
+			"Enabled":        {Path: "Enabled"},
+			"EventSourceArn": {Path: "EventSourceArn"},
+			"FilterCriteria": {
+				Path:   "FilterCriteria.Filters",
+				Fields: map[string]string{"pattern": "Pattern"},
+			},
+			"Function":                       {Path: "FunctionName"},
+			"FunctionResponseTypes":          {Path: "FunctionResponseTypes"},
+			"MaximumBatchingWindowInSeconds": {Path: "MaximumBatchingWindowInSeconds"},
+			"ScalingConfig":                  {Path: "ScalingConfig"},
+			"StartingPosition":               {Path: "StartingPosition"},
+		},
+	},
+	"aws:lambda_function": {
+		Type:         "AWS::Lambda::Function",
+		NameProperty: "FunctionName",
+		Static:       map[string]any{"PackageType": "Image"},
+		Properties: map[string]propertyMapping{
+			// Code is a local path, uploaded by `aws cloudformation package`
+			"Code": {Path: "Code"},
+			"EfsAccessPoint": {
+				Path:            "FileSystemConfigs[]",
+				AttributeFields: map[string]string{"Arn": "Arn", "LocalMountPath": "RootDirectory.Path"},
+			},
+			"EnvironmentVariables":         {Path: "Environment.Variables"},
+			"ExecutionRole":                {Path: "Role", Attribute: "Arn"},
+			"Handler":                      {Path: "Handler"},
+			"Image":                        {Path: "Code.ImageUri"},
+			"Layers":                       {Path: "Layers"},
+			"LogGroup":                     {Path: "LoggingConfig.LogGroup"},
+			"MemorySize":                   {Path: "MemorySize"},
+			"PackageType":                  {Path: "PackageType"},
+			"ReservedConcurrentExecutions": {Path: "ReservedConcurrentExecutions"},
+			"Runtime":                      {Path: "Runtime"},
+			"SecurityGroups":               {Path: "VpcConfig.SecurityGroupIds"},
+			"Subnets":                      {Path: "VpcConfig.SubnetIds"},
+			"Timeout":                      {Path: "Timeout"},
+		},
+		Attributes: map[string]string{
+			"Arn":                  "Arn",
+			"FunctionName":         "",
+			"LambdaIntegrationUri": "arn:${AWS::Partition}:apigateway:${AWS::Region}:lambda:path/2015-03-31/functions/${Arn}/invocations",
+		},
+	},
+	"aws:lambda_layer_version": {
+		Type:         "AWS::Lambda::LayerVersion",
+		NameProperty: "LayerName",
+		Properties: map[string]propertyMapping{
+			// Code is a local path, uploaded by `aws cloudformation package`
+			"Code":                    {Path: "Content"},
+			"CompatibleArchitectures": {Path: "CompatibleArchitectures"},
+			"CompatibleRuntimes":      {Path: "CompatibleRuntimes"},
+			"Description":             {Path: "Description"},
+			"LicenseInfo":             {Path: "LicenseInfo"},
+		},
+		Attributes: map[string]string{"Arn": ""},
+	},
+	"aws:lambda_permission": {
+		Type: "AWS::Lambda::Permission",
+		Properties: map[string]propertyMapping{
+			"Action":    {Path: "Action"},
+			"Function":  {Path: "FunctionName", Attribute: "Arn"},
+			"Principal": {Path: "Principal"},
+			"Source":    {Path: "SourceArn"},
+		},
+	},
+	"aws:listener_certificate": {
+		Type: "AWS::ElasticLoadBalancingV2::ListenerCertificate",
+		Properties: map[string]propertyMapping{
+			"Certificate": {Path: "Certificates[0].CertificateArn"},
+			"Listener":    {Path: "ListenerArn"},
+		},
+	},
+	"aws:load_balancer": {
+		Type: "AWS::ElasticLoadBalancingV2::LoadBalancer",
+		Properties: map[string]propertyMapping{
+			"IpAddressType":          {Path: "IpAddressType"},
+			"LoadBalancerAttributes": {Path: "LoadBalancerAttributes", Convert: tagList},
+			"Scheme":                 {Path: "Scheme"},
+			"SecurityGroups":         {Path: "SecurityGroups"},
+			"Subnets":                {Path: "Subnets"},
+			"Tags":                   {Path: "Tags", Convert: tagList},
+			"Type":                   {Path: "Type"},
+		},
+		Attributes: map[string]string{
+			"Arn":              "",
+			"ArnSuffix":        "LoadBalancerFullName",
+			"DnsName":          "DNSName",
+			"NlbUri":           "http://${DnsName}",
+			"OAuthCallbackUrl": "https://${DnsName}/oauth2/idpresponse",
+			"ZoneId":           "CanonicalHostedZoneID",
+		},
+	},
+	"aws:load_balancer_listener": {
+		Type: "AWS::ElasticLoadBalancingV2::Listener",
+		Properties: map[string]propertyMapping{
+			"Certificate":    {Path: "Certificates[0].CertificateArn"},
+			"DefaultActions": {Path: "DefaultActions", Fields: listenerActionFields, Convert: cognitoUserPoolArns},
+			"LoadBalancer":   {Path: "LoadBalancerArn"},
+			"Port":           {Path: "Port"},
+			"Protocol":       {Path: "Protocol"},
+		},
+		Attributes: map[string]string{
+			"Arn": "",
+		},
+	},
+	"aws:load_balancer_listener_rule": {
+		Type: "AWS::ElasticLoadBalancingV2::ListenerRule",
+		Properties: map[string]propertyMapping{
+			"Actions": {Path: "Actions", Fields: listenerActionFields, Convert: cognitoUserPoolArns},
+			"Conditions": {
+				Path: "Conditions",
+				Fields: map[string]string{
+					"HostHeader":        "HostHeaderConfig",
+					"HttpHeader":        "HttpHeaderConfig",
+					"HttpRequestMethod": "HttpRequestMethodConfig",
+					"PathPattern":       "PathPatternConfig",
+					"QueryString":       "QueryStringConfig",
+					"SourceIp":          "SourceIpConfig",
+				},
+			},
+			"Listener": {Path: "ListenerArn"},
+			"Priority": {Path: "Priority"},
+		},
+		Attributes: map[string]string{
+			"Arn": "",
+		},
+	},
+	"aws:log_group": {
+		Type: "AWS::Logs::LogGroup",
+		Properties: map[string]propertyMapping{
+			"KmsKey":          {Path: "KmsKeyId", Attribute: "Arn"},
+			"LogGroupName":    {Path: "LogGroupName"},
+			"RetentionInDays": {Path: "RetentionInDays"},
+		},
+		Attributes: map[string]string{
+			"Arn":          "Arn",
+			"LogGroupName": "",
+		},
+	},
+	"aws:log_metric_filter": {
+		Type:         "AWS::Logs::MetricFilter",
+		NameProperty: "FilterName",
+		Properties: map[string]propertyMapping{
+			"FilterPattern":        {Path: "FilterPattern"},
+			"LogGroup":             {Path: "LogGroupName"},
+			"MetricTransformation": {Path: "MetricTransformations[]"},
+		},
+	},
+	"aws:nat_gateway": {
+		Type: "AWS::EC2::NatGateway",
+		Properties: map[string]propertyMapping{
+			"ElasticIp": {Path: "AllocationId", Attribute: "AllocationId"},
+			"Subnet":    {Path: "SubnetId"},
+		},
+	},
+	"aws:private_dns_namespace": {
+		Type:         "AWS::ServiceDiscovery::PrivateDnsNamespace",
+		NameProperty: "Name",
+		Properties: map[string]propertyMapping{
+			"Vpc": {Path: "Vpc"},
+		},
+		Attributes: map[string]string{
+			"Arn": "Arn",
+			"Id":  "Id",
+		},
+	},
+	"aws:rds_instance": {
+		Type: "AWS::RDS::DBInstance",
+		SecretParameters: map[string]string{
+			"MasterUsername":     "Username",
+			"MasterUserPassword": "Password",
+		},
+		Properties: map[string]propertyMapping{
+			"AllocatedStorage":                 {Path: "AllocatedStorage"},
+			"DatabaseName":                     {Path: "DBName"},
+			"Engine":                           {Path: "Engine"},
+			"EngineVersion":                    {Path: "EngineVersion"},
+			"IamDatabaseAuthenticationEnabled": {Path: "EnableIAMDatabaseAuthentication"},
+			"InstanceClass":                    {Path: "DBInstanceClass"},
+			"KmsKey":                           {Path: "KmsKeyId", Attribute: "Arn"},
+			"SecurityGroups":                   {Path: "VPCSecurityGroups"},
+			"SubnetGroup":                      {Path: "DBSubnetGroupName"},
+		},
+		Attributes: map[string]string{
+			"Address":                "Endpoint.Address",
+			"CredentialsSecretValue": `{"username":"${Username}","password":"${Password}","engine":"${Engine}","host":"${Address}","port":${Port},"dbname":"${DatabaseName}"}`,
+			"DbiResourceId":          "DbiResourceId",
+			"Endpoint":               "${Address}:${Port}",
+			"Identifier":             "",
+			"Port":                   "Endpoint.Port",
+			"RdsConnectionArn":       "arn:${AWS::Partition}:rds-db:${AWS::Region}:${AWS::AccountId}:dbuser:${DbiResourceId}/${Username}",
+		},
+		Adjust: func(props map[string]any) {
+			// A KMS key is only used when the storage is encrypted
+			if _, ok := props["KmsKeyId"]; ok {
+				props["StorageEncrypted"] = true
+			}
+		},
+	},
+	"aws:rds_proxy": {
+		Type:         "AWS::RDS::DBProxy",
+		NameProperty: "DBProxyName",
+		Properties: map[string]propertyMapping{
+			"Auths": {
+				Path:   "Auth",
+				Fields: map[string]string{"IamAuth": "IAMAuth"},
+			},
+			"DebugLogging":      {Path: "DebugLogging"},
+			"EngineFamily":      {Path: "EngineFamily"},
+			"IdleClientTimeout": {Path: "IdleClientTimeout"},
+			"RequireTls":        {Path: "RequireTLS"},
+			"Role":              {Path: "RoleArn", Attribute: "Arn"},
+			"SecurityGroups":    {Path: "VpcSecurityGroupIds"},
+			"Subnets":           {Path: "VpcSubnetIds"},
+		},
+		Attributes: map[string]string{
+			"Arn":      "DBProxyArn",
+			"Endpoint": "Endpoint",
+			"Name":     "",
+		},
+	},
+	"aws:rds_proxy_target_group": {
+		Type: "AWS::RDS::DBProxyTargetGroup",
+		Properties: map[string]propertyMapping{
+			"ConnectionPoolConfigurationInfo": {Path: "ConnectionPoolConfigurationInfo"},
+			"RdsInstance":                     {Path: "DBInstanceIdentifiers[]"},
+			"RdsProxy":                        {Path: "DBProxyName"},
+			"TargetGroupName":                 {Path: "TargetGroupName"},
+		},
+	},
+	"aws:rds_subnet_group": {
+		Type:   "AWS::RDS::DBSubnetGroup",
+		Static: map[string]any{"DBSubnetGroupDescription": "Managed by Klotho"},
+		Properties: map[string]propertyMapping{
+			"Subnets": {Path: "SubnetIds"},
+			"Tags":    {Path: "Tags", Convert: tagList},
+		},
+		Attributes: map[string]string{
+			"Name": "",
+		},
+	},
+	"aws:rest_api": {
+		Type:         "AWS::ApiGateway::RestApi",
+		NameProperty: "Name",
+		Properties: map[string]propertyMapping{
+			"BinaryMediaTypes": {Path: "BinaryMediaTypes"},
+		},
+		Attributes: map[string]string{
+			"ChildResources": "arn:${AWS::Partition}:execute-api:${AWS::Region}:${AWS::AccountId}:${Id}/*",
+			"Id":             "",
+			"RootResourceId": "RootResourceId",
+		},
+	},
+	"aws:route53_hosted_zone": {
+		Type:          "AWS::Route53::HostedZone",
+		ParameterType: "AWS::Route53::HostedZone::Id",
+		Properties: map[string]propertyMapping{
+			"Comment":    {Path: "HostedZoneConfig.Comment"},
+			"DomainName": {Path: "Name"},
+			"Tags":       {Path: "HostedZoneTags", Convert: tagList},
+		},
+		Attributes: map[string]string{
+			"NameServers": "NameServers",
+			"ZoneId":      "",
+		},
+	},
+	"aws:route53_record": {
+		Type: "AWS::Route53::RecordSet",
+		Properties: map[string]propertyMapping{
+			"AliasTarget": {
+				Path:   "AliasTarget",
+				Fields: map[string]string{"DnsName": "DNSName"},
+			},
+			"HostedZone": {Path: "HostedZoneId"},
+			"RecordName": {Path: "Name"},
+			"Records":    {Path: "ResourceRecords"},
+			"Ttl":        {Path: "TTL"},
+			"Type":       {Path: "Type"},
+		},
+	},
+	"aws:route_table": {
+		Type: "AWS::EC2::RouteTable",
+		Properties: map[string]propertyMapping{
+			"Vpc": {Path: "VpcId"},
+			"Routes": {
+				Fields: map[string]string{
+					"CidrBlock":            "DestinationCidrBlock",
+					"Gateway":              "GatewayId",
+					"NatGateway":           "NatGatewayId",
+					"TransitGateway":       "TransitGatewayId",
+					"VpcPeeringConnection": "VpcPeeringConnectionId",
+				},
+				Child: &childMapping{Type: "AWS::EC2::Route", Parent: "RouteTableId"},
+			},
+		},
+	},
+	"aws:route_table_association": {
+		Type: "AWS::EC2::SubnetRouteTableAssociation",
+		Properties: map[string]propertyMapping{
+			"RouteTable": {Path: "RouteTableId"},
+			"Subnet":     {Path: "SubnetId"},
+		},
+	},
+	"aws:s3_bucket": {
+		Type: "AWS::S3::Bucket",
+		Properties: map[string]propertyMapping{
+			"IndexDocument": {Path: "WebsiteConfiguration.IndexDocument"},
+			"KmsKey": {
+				Path:      "BucketEncryption.ServerSideEncryptionConfiguration[0].ServerSideEncryptionByDefault.KMSMasterKeyID",
+				Attribute: "Arn",
+			},
+			"SSEAlgorithm": {
+				Path: "BucketEncryption.ServerSideEncryptionConfiguration[0].ServerSideEncryptionByDefault.SSEAlgorithm",
+			},
+		},
+		Attributes: map[string]string{
+			"AllBucketDirectory":       "${Arn}/*",
+			"Arn":                      "Arn",
+			"BucketName":               "",
+			"BucketRegionalDomainName": "RegionalDomainName",
+		},
+	},
+	"aws:s3_bucket_policy": {
+		Type: "AWS::S3::BucketPolicy",
+		Properties: map[string]propertyMapping{
+			"Bucket": {Path: "Bucket"},
+			"Policy": {Path: "PolicyDocument"},
+		},
+	},
+	"aws:secret": {
+		Type: "AWS::SecretsManager::Secret",
+		Properties: map[string]propertyMapping{
+			"KmsKey": {Path: "KmsKeyId", Attribute: "Arn"},
+		},
+		Attributes: map[string]string{
+			"Arn": "",
+			"Id":  "",
+		},
+	},
+	// Versions are the value of their secret in CloudFormation
+	"aws:secret_version": {
+		Embed: &embedMapping{Owner: "Secret"},
+		Properties: map[string]propertyMapping{
+			"Content": {Path: "SecretString"},
+		},
+		SecretParameters: map[string]string{
+			"SecretString": "Content",
+		},
+	},
+	"aws:security_group": {
+		Type:          "AWS::EC2::SecurityGroup",
+		NameProperty:  "GroupName",
+		Static:        map[string]any{"GroupDescription": "Managed by Klotho"},
+		ParameterType: "AWS::EC2::SecurityGroup::Id",
+		Properties: map[string]propertyMapping{
+			"Vpc":         {Path: "VpcId"},
+			"EgressRules": {Path: "SecurityGroupEgress", Convert: securityGroupRules("DestinationSecurityGroupId")},
+			// Ingress rules are separate resources so that rules allowing traffic from the group itself
+			// do not create a circular dependency.
+			"IngressRules": {
+				Convert: securityGroupRules("SourceSecurityGroupId"),
+				Child:   &childMapping{Type: "AWS::EC2::SecurityGroupIngress", Parent: "GroupId"},
+			},
+		},
+		Attributes: map[string]string{
+			"Id": "GroupId",
+		},
+	},
+	"aws:security_group_rule": {
+		Type:         "AWS::EC2::SecurityGroupIngress",
+		TypeProperty: "Type",
+		Types:        map[string]string{"egress": "AWS::EC2::SecurityGroupEgress"},
+		Properties: map[string]propertyMapping{
+			"CidrBlocks":      {Path: "CidrIp", Convert: singleCidr},
+			"Description":     {Path: "Description"},
+			"FromPort":        {Path: "FromPort"},
+			"Protocol":        {Path: "IpProtocol"},
+			"SecurityGroupId": {Path: "GroupId"},
+			"ToPort":          {Path: "ToPort"},
+		},
+	},
+	"aws:ses_email_identity": {
+		Type: "AWS::SES::EmailIdentity",
+		Properties: map[string]propertyMapping{
+			"EmailIdentity": {Path: "EmailIdentity"},
+		},
+		Attributes: map[string]string{
+			"Arn": "arn:${AWS::Partition}:ses:${AWS::Region}:${AWS::AccountId}:identity/${EmailIdentity}",
+		},
+	},
+	"aws:sfn_state_machine": {
+		Type:         "AWS::StepFunctions::StateMachine",
+		NameProperty: "StateMachineName",
+		Properties: map[string]propertyMapping{
+			"Definition":       {Path: "Definition"},
+			"Role":             {Path: "RoleArn", Attribute: "Arn"},
+			"StateMachineType": {Path: "StateMachineType"},
+			"Tags":             {Path: "Tags", Convert: tagList},
+		},
+		Attributes: map[string]string{
+			"ApiStartExecutionTemplate": `{"input": "$util.escapeJavaScript($input.json('$'))", "stateMachineArn": "${Arn}"}`,
+			"ApiStartExecutionUri":      "arn:${AWS::Partition}:apigateway:${AWS::Region}:states:action/StartExecution",
+			"Arn":                       "Arn",
+		},
+	},
+	"aws:sns_subscription": {
+		Type: "AWS::SNS::Subscription",
+		Properties: map[string]propertyMapping{
+			"Endpoint":           {Path: "Endpoint"},
+			"FilterPolicy":       {Path: "FilterPolicy", Convert: jsonObject},
+			"Protocol":           {Path: "Protocol"},
+			"RawMessageDelivery": {Path: "RawMessageDelivery"},
+			"Topic":              {Path: "TopicArn"},
+		},
+	},
+	"aws:sns_topic": {
+		Type:         "AWS::SNS::Topic",
+		NameProperty: "TopicName",
+		Properties: map[string]propertyMapping{
+			"ContentBasedDeduplication": {Path: "ContentBasedDeduplication"},
+			"DisplayName":               {Path: "DisplayName"},
+			"FifoTopic":                 {Path: "FifoTopic"},
+			"Tags":                      {Path: "Tags", Convert: tagList},
+		},
+		Attributes: map[string]string{
+			"Arn": "TopicArn",
+		},
+		Adjust: func(props map[string]any) {
+			// FIFO topic names must end in .fifo
+			if props["FifoTopic"] == true {
+				props["TopicName"] = fmt.Sprintf("%s.fifo", props["TopicName"])
+			}
+		},
+	},
+	"aws:sqs_queue": {
+		Type: "AWS::SQS::Queue",
+		Properties: map[string]propertyMapping{
+			"DelaySeconds":      {Path: "DelaySeconds"},
+			"FifoQueue":         {Path: "FifoQueue"},
+			"KmsKey":            {Path: "KmsMasterKeyId", Attribute: "Arn"},
+			"MaxMessageSize":    {Path: "MaximumMessageSize"},
+			"Tags":              {Path: "Tags", Convert: tagList},
+			"VisibilityTimeout": {Path: "VisibilityTimeout"},
+		},
+		Attributes: map[string]string{
+			"Arn":       "Arn",
+			"QueueName": "QueueName",
+		},
+	},
+	"aws:sqs_queue_policy": {
+		Type: "AWS::SQS::QueuePolicy",
+		Properties: map[string]propertyMapping{
+			"Policy": {Path: "PolicyDocument"},
+			"Queue":  {Path: "Queues[]"},
+		},
+	},
+	"aws:subnet": {
+		Type:          "AWS::EC2::Subnet",
+		ParameterType: "AWS::EC2::Subnet::Id",
+		Properties: map[string]propertyMapping{
+			"AvailabilityZone":    {Path: "AvailabilityZone"},
+			"CidrBlock":           {Path: "CidrBlock"},
+			"MapPublicIpOnLaunch": {Path: "MapPublicIpOnLaunch"},
+			"Vpc":                 {Path: "VpcId"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	"aws:target_group": {
+		Type: "AWS::ElasticLoadBalancingV2::TargetGroup",
+		Properties: map[string]propertyMapping{
+			"HealthCheck": {Convert: healthCheck},
+			"LambdaMultiValueHeadersEnabled": {
+				Path:    "TargetGroupAttributes[]",
+				Convert: targetGroupAttribute("lambda.multi_value_headers.enabled"),
+			},
+			"Port":       {Path: "Port"},
+			"Protocol":   {Path: "Protocol"},
+			"Tags":       {Path: "Tags", Convert: tagList},
+			"TargetType": {Path: "TargetType"},
+			"Targets":    {Path: "Targets"},
+			"Vpc":        {Path: "VpcId"},
+		},
+		Attributes: map[string]string{
+			"Arn": "",
+		},
+	},
+	"aws:vpc": {
+		Type:          "AWS::EC2::VPC",
+		ParameterType: "AWS::EC2::VPC::Id",
+		Properties: map[string]propertyMapping{
+			"CidrBlock":          {Path: "CidrBlock"},
+			"EnableDnsHostnames": {Path: "EnableDnsHostnames"},
+			"EnableDnsSupport":   {Path: "EnableDnsSupport"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	"aws:vpc_endpoint": {
+		Type: "AWS::EC2::VPCEndpoint",
+		Properties: map[string]propertyMapping{
+			"RouteTables":     {Path: "RouteTableIds"},
+			"SecurityGroups":  {Path: "SecurityGroupIds"},
+			"ServiceName":     {Path: "ServiceName", Convert: vpcEndpointService},
+			"Subnets":         {Path: "SubnetIds"},
+			"Vpc":             {Path: "VpcId"},
+			"VpcEndpointType": {Path: "VpcEndpointType"},
+		},
+		Adjust: func(props map[string]any) {
+			// Interface endpoints are placed in subnets, gateway endpoints are added to route tables
+			if props["VpcEndpointType"] == "Interface" {
+				props["PrivateDnsEnabled"] = true
+				delete(props, "RouteTableIds")
+			} else {
+				delete(props, "SubnetIds")
+				delete(props, "SecurityGroupIds")
+			}
+		},
+	},
+	"aws:vpc_flow_log": {
+		Type:   "AWS::EC2::FlowLog",
+		Static: map[string]any{"ResourceType": "VPC"},
+		Properties: map[string]propertyMapping{
+			"IamRole":                {Path: "DeliverLogsPermissionArn", Attribute: "Arn"},
+			"LogDestinationType":     {Path: "LogDestinationType"},
+			"LogFormat":              {Path: "LogFormat"},
+			"LogGroup":               {Path: "LogDestination", Attribute: "Arn"},
+			"MaxAggregationInterval": {Path: "MaxAggregationInterval"},
+			"S3Bucket":               {Path: "LogDestination", Attribute: "Arn"},
+			"Tags":                   {Path: "Tags", Convert: tagList},
+			"TrafficType":            {Path: "TrafficType"},
+			"Vpc":                    {Path: "ResourceId"},
+		},
+	},
+	"aws:vpc_link": {
+		Type:         "AWS::ApiGateway::VpcLink",
+		NameProperty: "Name",
+		Properties: map[string]propertyMapping{
+			"Target": {Path: "TargetArns[]"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+	"aws:vpc_peering_connection": {
+		Type: "AWS::EC2::VPCPeeringConnection",
+		Properties: map[string]propertyMapping{
+			"PeerOwnerId": {Path: "PeerOwnerId"},
+			"PeerRegion":  {Path: "PeerRegion"},
+			"PeerVpc":     {Path: "PeerVpcId"},
+			"Tags":        {Path: "Tags", Convert: tagList},
+			"Vpc":         {Path: "VpcId"},
+		},
+		Attributes: map[string]string{
+			"Id": "",
+		},
+	},
+}
+
+// keySchema converts a DynamoDB key attribute name into a KeySchema element.
+func keySchema(keyType string) func(v any) (any, error) {
+	return func(v any) (any, error) {
+		return map[string]any{"AttributeName": v, "KeyType": keyType}, nil
+	}
+}
+
+// streamSpecification converts a table's StreamSpecification. CloudFormation has no StreamEnabled flag: the stream is
+// enabled by the presence of the specification.
+func streamSpecification(v any) (any, error) {
+	spec, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("stream specification is not a map: %v (%[1]T)", v)
+	}
+	if enabled, _ := spec["StreamEnabled"].(bool); !enabled {
+		return nil, nil
+	}
+	return map[string]any{"StreamViewType": spec["StreamViewType"]}, nil
+}
+
+// keyPolicy prepends a statement giving the account full access to the key, without which IAM policies cannot
+// grant access to it.
+func keyPolicy(v any) (any, error) {
+	policy, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected key policy to be a map, got %T", v)
+	}
+	policy = copyMap(policy)
+	statements, _ := policy["Statement"].([]any)
+	policy["Statement"] = append([]any{map[string]any{
+		"Effect":    "Allow",
+		"Principal": map[string]any{"AWS": map[string]any{"Fn::Sub": "arn:${AWS::Partition}:iam::${AWS::AccountId}:root"}},
+		"Action":    "kms:*",
+		"Resource":  "*",
+	}}, statements...)
+	return policy, nil
+}
+
+// serviceKeyStatement converts the names of services which use a key on their own behalf into a key policy statement.
+func serviceKeyStatement(v any) (any, error) {
+	names, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected service principals to be a list, got %T", v)
+	}
+	seen := make(map[any]bool, len(names))
+	services := make([]any, 0, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if name == "logs" {
+			// CloudWatch Logs is the only service whose principal is regional
+			services = append(services, map[string]any{"Fn::Sub": "logs.${AWS::Region}.amazonaws.com"})
+			continue
+		}
+		services = append(services, fmt.Sprintf("%s.amazonaws.com", name))
+	}
+	return map[string]any{
+		"Effect":    "Allow",
+		"Principal": map[string]any{"Service": services},
+		"Action": []any{
+			"kms:Decrypt*",
+			"kms:Describe*",
+			"kms:Encrypt*",
+			"kms:GenerateDataKey*",
+			"kms:ReEncrypt*",
+		},
+		"Resource": "*",
+	}, nil
+}
+
+// tagList converts a map of tags into the CloudFormation list of Key/Value pairs.
+func tagList(v any) (any, error) {
+	tags, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("tags must be a map, got %T", v)
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	list := make([]any, len(keys))
+	for i, k := range keys {
+		list[i] = map[string]any{"Key": k, "Value": tags[k]}
+	}
+	return list, nil
+}
+
+// nameValueList converts a map, such as metric dimensions, into the CloudFormation list of Name/Value pairs.
+func nameValueList(v any) (any, error) {
+	m, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a map, got %T", v)
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	list := make([]any, len(keys))
+	for i, k := range keys {
+		list[i] = map[string]any{"Name": k, "Value": m[k]}
+	}
+	return list, nil
+}
+
+// enabledFlag converts a boolean into the `ENABLED` or `DISABLED` value some properties take instead.
+func enabledFlag(v any) (any, error) {
+	enabled, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("expected a bool, got %T", v)
+	}
+	if enabled {
+		return "ENABLED", nil
+	}
+	return "DISABLED", nil
+}
+
+// containerInsights converts the ContainerInsights flag of a cluster into its cluster settings.
+func containerInsights(v any) (any, error) {
+	if enabled, _ := v.(bool); !enabled {
+		return nil, nil
+	}
+	return []any{map[string]any{"Name": "containerInsights", "Value": "enabled"}}, nil
+}
+
+// portMappings lowercases the protocol of each port mapping, since ECS does not accept the uppercase form.
+func portMappings(v any) (any, error) {
+	mappings, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("port mappings must be a list, got %T", v)
+	}
+	out := make([]any, len(mappings))
+	for i, m := range mappings {
+		mapping, ok := m.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("port mapping must be an object, got %T", m)
+		}
+		mapping = copyMap(mapping)
+		if protocol, ok := mapping["Protocol"].(string); ok {
+			mapping["Protocol"] = strings.ToLower(protocol)
+		}
+		out[i] = mapping
+	}
+	return out, nil
+}
+
+// healthCheck converts a target group's health check into the flat target group properties CloudFormation uses.
+func healthCheck(v any) (any, error) {
+	check, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("health check must be an object, got %T", v)
+	}
+	names := map[string]string{
+		"Enabled":            "HealthCheckEnabled",
+		"HealthyThreshold":   "HealthyThresholdCount",
+		"Interval":           "HealthCheckIntervalSeconds",
+		"Path":               "HealthCheckPath",
+		"Port":               "HealthCheckPort",
+		"Protocol":           "HealthCheckProtocol",
+		"Timeout":            "HealthCheckTimeoutSeconds",
+		"UnhealthyThreshold": "UnhealthyThresholdCount",
+	}
+	props := make(map[string]any, len(check))
+	for k, elem := range check {
+		if k == "Matcher" {
+			props["Matcher"] = map[string]any{"HttpCode": elem}
+			continue
+		}
+		name, ok := names[k]
+		if !ok {
+			return nil, fmt.Errorf("unsupported health check field %s", k)
+		}
+		if k == "Port" {
+			// The port is a string in CloudFormation, to allow `traffic-port`
+			elem = fmt.Sprint(elem)
+		}
+		props[name] = elem
+	}
+	return props, nil
+}
+
+// targetGroupAttribute converts a value into the target group attribute of the given key.
+func targetGroupAttribute(key string) func(v any) (any, error) {
+	return func(v any) (any, error) {
+		return map[string]any{"Key": key, "Value": fmt.Sprint(v)}, nil
+	}
+}
+
+// cognitoUserPoolArns replaces the user pool `Ref` (its ID) of each listener action authenticating with Cognito
+// with the ARN of the user pool.
+func cognitoUserPoolArns(v any) (any, error) {
+	actions, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("listener actions must be a list, got %T", v)
+	}
+	out := make([]any, len(actions))
+	for i, a := range actions {
+		action, ok := a.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("listener action must be an object, got %T", a)
+		}
+		if config, ok := action["AuthenticateCognitoConfig"].(map[string]any); ok {
+			config = copyMap(config)
+			config["UserPoolArn"] = getAtt(config["UserPoolArn"], "Arn")
+			action = copyMap(action)
+			action["AuthenticateCognitoConfig"] = config
+		}
+		out[i] = action
+	}
+	return out, nil
+}
+
+// rootResourceDefault sets the given API resource property to the root resource of the REST API when it is not set.
+func rootResourceDefault(key string) func(props map[string]any) {
+	return func(props map[string]any) {
+		if _, ok := props[key]; !ok {
+			props[key] = getAtt(props["RestApiId"], "RootResourceId")
+		}
+	}
+}
+
+// getAtt converts a `Ref` into the `Fn::GetAtt` of the attribute of the same resource. Other values are kept as-is.
+func getAtt(v any, attribute string) any {
+	r, ok := v.(map[string]any)
+	if !ok {
+		return v
+	}
+	logicalId, ok := r["Ref"].(string)
+	if !ok || len(r) != 1 {
+		return v
+	}
+	return map[string]any{"Fn::GetAtt": []any{logicalId, attribute}}
+}
+
+// dashboardBody renders the dashboard's alarm widgets as the JSON document CloudFormation expects. Since the alarm ARNs
+// are only known at deploy time, the document is assembled with `Fn::Join`.
+func dashboardBody(v any) (any, error) {
+	widgets, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("widgets must be a list, got %T", v)
+	}
+	parts := []any{`{"widgets":[`}
+	for i, w := range widgets {
+		widget, ok := w.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("widget %d must be a map, got %T", i, w)
+		}
+		if i > 0 {
+			parts = append(parts, ",")
+		}
+		title, err := json.Marshal(widget["Title"])
+		if err != nil {
+			return nil, fmt.Errorf("could not marshal title of widget %d: %w", i, err)
+		}
+		parts = append(parts, fmt.Sprintf(
+			`{"type":"alarm","width":%v,"height":%v,"properties":{"title":%s,"alarms":[`,
+			widget["Width"], widget["Height"], title,
+		))
+		alarms, _ := widget["Alarms"].([]any)
+		for j, alarm := range alarms {
+			if j > 0 {
+				parts = append(parts, ",")
+			}
+			parts = append(parts, `"`, alarm, `"`)
+		}
+		parts = append(parts, "]}}")
+	}
+	parts = append(parts, "]}")
+	return map[string]any{"Fn::Join": []any{"", parts}}, nil
+}
+
+// jsonObject converts a JSON document string into the object CloudFormation expects.
+func jsonObject(v any) (any, error) {
+	str, ok := v.(string)
+	if !ok {
+		return v, nil
+	}
+	var obj any
+	if err := json.Unmarshal([]byte(str), &obj); err != nil {
+		return nil, fmt.Errorf("could not parse JSON document: %w", err)
+	}
+	return obj, nil
+}
+
+// securityGroupRules converts the knowledge base rules into CloudFormation rules. Since CloudFormation rules take a
+// single CIDR, rules with multiple CidrBlocks are split. `Self` rules set the peerKey to the security group itself.
+func securityGroupRules(peerKey string) func(v any) (any, error) {
+	return func(v any) (any, error) {
+		rules, ok := v.([]any)
+		if !ok {
+			return nil, fmt.Errorf("security group rules must be a list, got %T", v)
+		}
+		var out []any
+		for _, r := range rules {
+			rule, ok := r.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("security group rule must be an object, got %T", r)
+			}
+			base := make(map[string]any)
+			for _, key := range []string{"Description", "FromPort", "ToPort"} {
+				if v, ok := rule[key]; ok {
+					base[key] = v
+				}
+			}
+			if v, ok := rule["Protocol"]; ok {
+				base["IpProtocol"] = v
+			}
+			if self, _ := rule["Self"].(bool); self {
+				peer := copyMap(base)
+				peer[peerKey] = selfRef{}
+				out = append(out, peer)
+			}
+			cidrs, _ := rule["CidrBlocks"].([]any)
+			for _, cidr := range cidrs {
+				peer := copyMap(base)
+				peer["CidrIp"] = cidr
+				out = append(out, peer)
+			}
+		}
+		return out, nil
+	}
+}
+
+func copyMap(m map[string]any) map[string]any {
+	c := make(map[string]any, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// stringValues converts the numbers within a value into strings, for properties CloudFormation only accepts as strings.
+func stringValues(v any) (any, error) {
+	switch v := v.(type) {
+	case int, float64:
+		return fmt.Sprint(v), nil
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i], _ = stringValues(elem)
+		}
+		return out, nil
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, elem := range v {
+			out[k], _ = stringValues(elem)
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// lifecyclePolicies converts a file system's lifecycle policies into the list of single-transition policies
+// CloudFormation expects.
+func lifecyclePolicies(v any) (any, error) {
+	policies, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("lifecycle policies must be a map, got %T", v)
+	}
+	keys := make([]string, 0, len(policies))
+	for k := range policies {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	list := make([]any, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, map[string]any{k: policies[k]})
+	}
+	return list, nil
+}
+
+// fargateSelectors converts the labels of each Fargate profile selector into the CloudFormation list of Key/Value
+// pairs.
+func fargateSelectors(v any) (any, error) {
+	selectors, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("selectors must be a list, got %T", v)
+	}
+	out := make([]any, len(selectors))
+	for i, elem := range selectors {
+		selector, ok := elem.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("selector must be an object, got %T", elem)
+		}
+		selector = copyMap(selector)
+		if labels, ok := selector["Labels"]; ok {
+			list, err := tagList(labels)
+			if err != nil {
+				return nil, err
+			}
+			selector["Labels"] = list
+		}
+		out[i] = selector
+	}
+	return out, nil
+}
+
+// cacheLogDelivery converts a cache cluster's log group into the delivery of both its slow and engine logs.
+func cacheLogDelivery(v any) (any, error) {
+	delivery := func(logType, format string) map[string]any {
+		return map[string]any{
+			"DestinationType": "cloudwatch-logs",
+			"DestinationDetails": map[string]any{
+				"CloudWatchLogsDetails": map[string]any{"LogGroup": v},
+			},
+			"LogFormat": format,
+			"LogType":   logType,
+		}
+	}
+	return []any{delivery("slow-log", "text"), delivery("engine-log", "json")}, nil
+}
+
+// singleCidr converts a rule's CIDR blocks into the single CIDR a CloudFormation security group rule takes.
+func singleCidr(v any) (any, error) {
+	cidrs, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("CIDR blocks must be a list, got %T", v)
+	}
+	switch len(cidrs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return cidrs[0], nil
+	}
+	return nil, fmt.Errorf("security group rules support a single CIDR block, got %d", len(cidrs))
+}
+
+// vpcEndpointService converts the name of an AWS service into the endpoint service name in the stack's region.
+func vpcEndpointService(v any) (any, error) {
+	service, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("service name must be a string, got %T", v)
+	}
+	return map[string]any{"Fn::Sub": "com.amazonaws.${AWS::Region}." + service}, nil
+}
+
+// integrationLbUri is the URI of a private integration, which proxies the integration's route to its load balancer.
+func integrationLbUri(r *construct.Resource) (string, error) {
+	if _, ok := r.Properties["Target"].(construct.ResourceId); !ok {
+		return "", fmt.Errorf("%s has no load balancer target", r.ID)
+	}
+	route, ok := r.Properties["Route"].(string)
+	if !ok {
+		return "", fmt.Errorf("%s has no route", r.ID)
+	}
+	return "http://${Target.DnsName}" + strings.Replace(route, "+", "", 1), nil
+}
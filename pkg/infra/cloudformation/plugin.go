@@ -0,0 +1,51 @@
+package cloudformation
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/klothoplatform/klotho/pkg/engine2/solution_context"
+	kio "github.com/klothoplatform/klotho/pkg/io"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	Config struct {
+		AppName string
+	}
+
+	Plugin struct {
+		Config *Config
+	}
+)
+
+const templatePath = "template.yaml"
+
+func (p Plugin) Name() string {
+	return "cloudformation"
+}
+
+func (p Plugin) Translate(ctx solution_context.SolutionContext) ([]kio.File, error) {
+	var description string
+	if p.Config.AppName != "" {
+		description = fmt.Sprintf("%s stack generated by Klotho", p.Config.AppName)
+	}
+	tmpl, err := NewTemplate(ctx.DeploymentGraph(), description)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := new(bytes.Buffer)
+	enc := yaml.NewEncoder(buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(tmpl); err != nil {
+		return nil, fmt.Errorf("could not marshal CloudFormation template: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return []kio.File{&kio.RawFile{
+		FPath:   templatePath,
+		Content: buf.Bytes(),
+	}}, nil
+}
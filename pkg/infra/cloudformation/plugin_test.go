@@ -0,0 +1,142 @@
+package cloudformation
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	engine "github.com/klothoplatform/klotho/pkg/engine2"
+	"github.com/klothoplatform/klotho/pkg/knowledge_base2/reader"
+	"github.com/klothoplatform/klotho/pkg/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// TestPlugin_Translate_EngineTestdata renders the solutions expected by the engine tests, so that every resource and
+// property reference the engine produces either has a CloudFormation mapping or fails with a clear error.
+func TestPlugin_Translate_EngineTestdata(t *testing.T) {
+	// unsupported are the solutions which use resources the provider cannot render, and the expected error
+	unsupported := map[string]string{
+		"apigw_v2":                    "aws:apigw_v2_api",
+		"aurora":                      "aws:rds_cluster",
+		"delete_resource_and_iacdeps": "aws:api_integration:rest_api_0:rest_api_0_integration_0 has no load balancer target",
+		"ecs_autoscaling":             "aws:appautoscaling_policy",
+		"event_triggers":              "aws:s3_bucket_notification",
+		"k8s_api":                     "kubernetes:helm_chart",
+		"lambda_alias":                "aws:lambda_alias",
+		"opensearch":                  "aws:opensearch_domain",
+		"redis_replication":           "aws:elasticache_replication_group",
+		"route53":                     "aws:api_base_path_mapping",
+		"route53_cloudfront":          "no CloudFormation attribute for aws:acm_certificate:cdn-acm_certificate#ValidationRecordName",
+		"route53_load_balancer":       "no CloudFormation attribute for aws:acm_certificate:web-cert#ValidationRecordName",
+		"secret_rotation":             "aws:secret_rotation",
+		"waf":                         "aws:wafv2_web_acl",
+	}
+
+	kb, err := reader.NewKBFromFs(templates.ResourceTemplates, templates.EdgeTemplates, templates.Models)
+	require.NoError(t, err)
+
+	paths, err := filepath.Glob("../../engine2/testdata/*.expect.yaml")
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".expect.yaml")
+		t.Run(name, func(t *testing.T) {
+			f, err := os.Open(path)
+			require.NoError(t, err)
+			defer f.Close()
+
+			var input construct.YamlGraph
+			require.NoError(t, yaml.NewDecoder(f).Decode(&input))
+
+			sol := engine.NewSolutionContext(kb)
+			require.NoError(t, sol.LoadGraph(input.Graph))
+
+			p := Plugin{Config: &Config{AppName: "test"}}
+			files, err := p.Translate(sol)
+			if wantErr, ok := unsupported[name]; ok {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+
+			buf := new(bytes.Buffer)
+			_, err = files[0].WriteTo(buf)
+			require.NoError(t, err)
+			var tmpl Template
+			require.NoError(t, yaml.Unmarshal(buf.Bytes(), &tmpl))
+			assertNoCycles(t, tmpl)
+		})
+	}
+}
+
+var subReference = regexp.MustCompile(`\$\{([a-zA-Z0-9]+)[.}]`)
+
+// assertNoCycles checks that no resource depends on itself, through `DependsOn` or references in its properties,
+// which CloudFormation rejects.
+func assertNoCycles(t *testing.T, tmpl Template) {
+	deps := make(map[string][]string, len(tmpl.Resources))
+	var references func(v any) []string
+	references = func(v any) []string {
+		var refs []string
+		switch v := v.(type) {
+		case map[string]any:
+			if ref, ok := v["Ref"].(string); ok {
+				refs = append(refs, ref)
+			}
+			if getAtt, ok := v["Fn::GetAtt"].([]any); ok && len(getAtt) > 0 {
+				refs = append(refs, getAtt[0].(string))
+			}
+			if sub, ok := v["Fn::Sub"].(string); ok {
+				for _, m := range subReference.FindAllStringSubmatch(sub, -1) {
+					refs = append(refs, m[1])
+				}
+			}
+			for _, elem := range v {
+				refs = append(refs, references(elem)...)
+			}
+		case []any:
+			for _, elem := range v {
+				refs = append(refs, references(elem)...)
+			}
+		}
+		return refs
+	}
+	for logicalId, r := range tmpl.Resources {
+		deps[logicalId] = append(r.DependsOn, references(r.Properties)...)
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(deps))
+	var visit func(logicalId string, path []string)
+	visit = func(logicalId string, path []string) {
+		switch state[logicalId] {
+		case visiting:
+			t.Errorf("circular dependency: %s -> %s", strings.Join(path, " -> "), logicalId)
+			return
+		case visited:
+			return
+		}
+		state[logicalId] = visiting
+		for _, dep := range deps[logicalId] {
+			if _, ok := tmpl.Resources[dep]; ok {
+				visit(dep, append(path, logicalId))
+			}
+		}
+		state[logicalId] = visited
+	}
+	for logicalId := range deps {
+		visit(logicalId, nil)
+	}
+}
@@ -0,0 +1,775 @@
+package cloudformation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	"github.com/klothoplatform/klotho/pkg/set"
+)
+
+type (
+	Template struct {
+		AWSTemplateFormatVersion string               `yaml:"AWSTemplateFormatVersion"`
+		Description              string               `yaml:"Description,omitempty"`
+		Parameters               map[string]Parameter `yaml:"Parameters,omitempty"`
+		Resources                map[string]Resource  `yaml:"Resources"`
+	}
+
+	Parameter struct {
+		Type        string `yaml:"Type"`
+		Description string `yaml:"Description,omitempty"`
+		NoEcho      bool   `yaml:"NoEcho,omitempty"`
+	}
+
+	Resource struct {
+		Type       string         `yaml:"Type"`
+		DependsOn  []string       `yaml:"DependsOn,omitempty"`
+		Properties map[string]any `yaml:"Properties,omitempty"`
+	}
+
+	templateCompiler struct {
+		graph construct.Graph
+		// logicalIds are the logical IDs of all the resources and parameters in the template
+		logicalIds map[construct.ResourceId]string
+		// parameters are the resources rendered as parameters instead of resources
+		parameters set.Set[construct.ResourceId]
+		// embedded are the resources rendered as part of another resource, mapped to the resource they are part of
+		embedded map[construct.ResourceId]construct.ResourceId
+		template *Template
+	}
+)
+
+const templateFormatVersion = "2010-09-09"
+
+var (
+	nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+	subPlaceholder  = regexp.MustCompile(`\$\{([a-zA-Z0-9.]+)\}`)
+)
+
+// NewTemplate renders the deployment graph as a CloudFormation template. Imported resources and resources which
+// CloudFormation cannot create (such as images) become template parameters. Graphs which contain any other resources
+// of a type without a mapping are not supported.
+func NewTemplate(g construct.Graph, description string) (*Template, error) {
+	resources, err := construct.ReverseTopologicalSort(g)
+	if err != nil {
+		return nil, err
+	}
+	tc := &templateCompiler{
+		graph:      g,
+		logicalIds: make(map[construct.ResourceId]string),
+		parameters: make(set.Set[construct.ResourceId]),
+		embedded:   make(map[construct.ResourceId]construct.ResourceId),
+		template: &Template{
+			AWSTemplateFormatVersion: templateFormatVersion,
+			Description:              description,
+			Parameters:               make(map[string]Parameter),
+			Resources:                make(map[string]Resource),
+		},
+	}
+	if err := tc.assignLogicalIds(resources); err != nil {
+		return nil, err
+	}
+
+	var errs error
+	for _, id := range resources {
+		if _, ok := pseudoResources[id.QualifiedTypeName()]; ok {
+			continue
+		}
+		if _, ok := tc.embedded[id]; ok {
+			continue
+		}
+		errs = errors.Join(errs, tc.renderResource(id))
+	}
+	// Embedded resources are rendered last, so that the resources they are part of already exist
+	for _, id := range resources {
+		if _, ok := tc.embedded[id]; ok {
+			errs = errors.Join(errs, tc.renderResource(id))
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return tc.template, nil
+}
+
+func (tc *templateCompiler) assignLogicalIds(resources []construct.ResourceId) error {
+	used := make(set.Set[string])
+	unsupported := make(set.Set[string])
+	for _, id := range resources {
+		if _, ok := pseudoResources[id.QualifiedTypeName()]; ok {
+			continue
+		}
+		r, err := tc.graph.Vertex(id)
+		if err != nil {
+			return err
+		}
+		mapping, hasMapping := resourceMappings[id.QualifiedTypeName()]
+		if mapping.Embed != nil && !r.Imported {
+			owner, ok := r.Properties[mapping.Embed.Owner].(construct.ResourceId)
+			if !ok {
+				return fmt.Errorf("%s is missing %s, the resource it is part of", id, mapping.Embed.Owner)
+			}
+			tc.embedded[id] = owner
+			continue
+		}
+
+		base := nonAlphanumeric.ReplaceAllString(
+			strcase.ToCamel(fmt.Sprintf("%s_%s_%s", id.Type, id.Namespace, id.Name)),
+			"",
+		)
+		logicalId := base
+		for i := 1; used.Contains(logicalId); i++ {
+			logicalId = fmt.Sprintf("%s%d", base, i)
+		}
+		used.Add(logicalId)
+		tc.logicalIds[id] = logicalId
+
+		switch {
+		case r.Imported || mapping.Parameter:
+			tc.parameters.Add(id)
+		case !hasMapping:
+			unsupported.Add(id.QualifiedTypeName())
+		}
+	}
+	for id, owner := range tc.embedded {
+		logicalId, ok := tc.logicalIds[owner]
+		if !ok || tc.parameters.Contains(owner) {
+			return fmt.Errorf("%s must be part of a resource in the stack, not %s", id, owner)
+		}
+		tc.logicalIds[id] = logicalId
+	}
+	if len(unsupported) > 0 {
+		types := unsupported.ToSlice()
+		sort.Strings(types)
+		return fmt.Errorf("resource types not supported by CloudFormation: %s", strings.Join(types, ", "))
+	}
+	return nil
+}
+
+func (tc *templateCompiler) renderResource(id construct.ResourceId) error {
+	logicalId := tc.logicalIds[id]
+	mapping, hasMapping := resourceMappings[id.QualifiedTypeName()]
+	if tc.parameters.Contains(id) {
+		paramType := mapping.ParameterType
+		if paramType == "" {
+			paramType = "String"
+		}
+		tc.template.Parameters[logicalId] = Parameter{
+			Type:        paramType,
+			Description: fmt.Sprintf("Value for %s", id),
+		}
+		return nil
+	}
+	if !hasMapping {
+		return fmt.Errorf("no CloudFormation mapping for %s", id.QualifiedTypeName())
+	}
+	r, err := tc.graph.Vertex(id)
+	if err != nil {
+		return err
+	}
+
+	props := make(map[string]any, len(mapping.Static)+len(r.Properties))
+	for k, v := range mapping.Static {
+		props[k] = copyValue(v)
+	}
+	if mapping.NameProperty != "" {
+		props[mapping.NameProperty] = id.Name
+	}
+	for _, path := range mapping.NamePaths {
+		setPath(props, path, id.Name)
+	}
+	names := make([]string, 0, len(r.Properties))
+	for name := range r.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs error
+	for _, name := range names {
+		pm, ok := mapping.Properties[name]
+		if !ok || r.Properties[name] == nil {
+			continue
+		}
+		var v any
+		if pm.AttributeFields != nil {
+			v, err = tc.attributeFields(r.Properties[name], pm.AttributeFields)
+		} else {
+			v, err = tc.value(r.Properties[name], pm.Attribute)
+		}
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("could not render %s#%s: %w", id, name, err))
+			continue
+		}
+		if pm.Fields != nil {
+			v = renameFields(v, pm.Fields)
+		}
+		if pm.Convert != nil {
+			v, err = pm.Convert(v)
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("could not convert %s#%s: %w", id, name, err))
+				continue
+			}
+			if v == nil {
+				// the property has no CloudFormation equivalent for this value
+				continue
+			}
+		}
+		if pm.Child != nil {
+			tc.renderChildren(logicalId+strcase.ToCamel(name), logicalId, pm.Child, v)
+			continue
+		}
+		if containsSelfRef(v) {
+			errs = errors.Join(errs, fmt.Errorf("%s#%s cannot reference its own resource", id, name))
+			continue
+		}
+		if pm.Path == "" {
+			obj, ok := v.(map[string]any)
+			if !ok {
+				errs = errors.Join(errs, fmt.Errorf("%s#%s must be an object to be merged, got %T", id, name, v))
+				continue
+			}
+			for k, elem := range obj {
+				props[k] = elem
+			}
+			continue
+		}
+		setPath(props, pm.Path, v)
+	}
+	if errs != nil {
+		return errs
+	}
+	for path, name := range mapping.SecretParameters {
+		if hasPath(props, path) {
+			continue
+		}
+		tc.template.Parameters[logicalId+name] = Parameter{
+			Type:        "String",
+			Description: fmt.Sprintf("Value for %s#%s", id, name),
+			NoEcho:      true,
+		}
+		setPath(props, path, ref(logicalId+name))
+	}
+	if mapping.Adjust != nil {
+		mapping.Adjust(props)
+	}
+
+	dependsOn, err := tc.dependsOn(id)
+	if err != nil {
+		return err
+	}
+	if ownerId, ok := tc.embedded[id]; ok {
+		owner := tc.template.Resources[logicalId]
+		if owner.Properties == nil {
+			owner.Properties = make(map[string]any)
+		}
+		var embedded any = props
+		if mapping.Embed.Property != "" {
+			embedded = props[mapping.Embed.Property]
+		}
+		if mapping.Embed.Path == "" {
+			for k, v := range props {
+				owner.Properties[k] = v
+			}
+		} else if embedded != nil {
+			setPath(owner.Properties, mapping.Embed.Path, embedded)
+		}
+
+		// Resources which depend on the owner (such as permissions referencing it) only needed to precede the
+		// embedded resource, and would be circular dependencies of the owner.
+		upstream, err := construct.AllUpstreamDependencies(tc.graph, ownerId)
+		if err != nil {
+			return err
+		}
+		circular := make(set.Set[string])
+		for _, up := range upstream {
+			circular.Add(tc.logicalIds[up])
+		}
+		deps := set.SetOf(owner.DependsOn...)
+		for _, dep := range dependsOn {
+			if !circular.Contains(dep) {
+				deps.Add(dep)
+			}
+		}
+		owner.DependsOn = deps.ToSlice()
+		sort.Strings(owner.DependsOn)
+		tc.template.Resources[logicalId] = owner
+		return nil
+	}
+	resourceType := mapping.Type
+	if mapping.TypeProperty != "" {
+		if t, ok := mapping.Types[fmt.Sprint(r.Properties[mapping.TypeProperty])]; ok {
+			resourceType = t
+		}
+	}
+	tc.template.Resources[logicalId] = Resource{
+		Type:       resourceType,
+		DependsOn:  dependsOn,
+		Properties: props,
+	}
+	return nil
+}
+
+// renderChildren adds a resource for each element of v, with the child's Parent property referencing the owner.
+func (tc *templateCompiler) renderChildren(prefix, owner string, child *childMapping, v any) {
+	elements, ok := v.([]any)
+	if !ok {
+		elements = []any{v}
+	}
+	for i, element := range elements {
+		props, ok := replaceSelfRef(element, ref(owner)).(map[string]any)
+		if !ok {
+			props = make(map[string]any)
+		}
+		props[child.Parent] = ref(owner)
+		tc.template.Resources[fmt.Sprintf("%s%d", prefix, i)] = Resource{
+			Type:       child.Type,
+			Properties: props,
+		}
+	}
+}
+
+// dependsOn returns the logical IDs of the resources the resource depends on in the deployment graph.
+func (tc *templateCompiler) dependsOn(id construct.ResourceId) ([]string, error) {
+	downstream, err := construct.DirectDownstreamDependencies(tc.graph, id)
+	if err != nil {
+		return nil, err
+	}
+	deps := make(set.Set[string])
+	for _, dep := range downstream {
+		logicalId, ok := tc.logicalIds[dep]
+		if !ok || tc.parameters.Contains(dep) || logicalId == tc.logicalIds[id] {
+			continue
+		}
+		deps.Add(logicalId)
+	}
+	dependsOn := deps.ToSlice()
+	sort.Strings(dependsOn)
+	return dependsOn, nil
+}
+
+// value converts a property value into its CloudFormation representation. The attribute is used for any
+// references to other resources within the value.
+func (tc *templateCompiler) value(v any, attribute string) (any, error) {
+	switch v := v.(type) {
+	case construct.ResourceId:
+		return tc.reference(v, attribute)
+
+	case construct.PropertyRef:
+		return tc.propertyRef(v)
+
+	case string, bool, int, float64, selfRef, nil:
+		return v, nil
+	}
+
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		list := make([]any, 0, val.Len())
+		for i := 0; i < val.Len(); i++ {
+			elem := val.Index(i)
+			if !elem.IsValid() || (elem.Kind() == reflect.Interface && elem.IsNil()) {
+				continue
+			}
+			out, err := tc.value(elem.Interface(), attribute)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, out)
+		}
+		return list, nil
+
+	case reflect.Map:
+		m := make(map[string]any, val.Len())
+		for _, key := range val.MapKeys() {
+			elem := val.MapIndex(key)
+			if !elem.IsValid() || elem.IsZero() {
+				continue
+			}
+			keyStr, ok := key.Interface().(string)
+			if !ok {
+				return nil, fmt.Errorf("map key is not a string (is %s)", key.Type())
+			}
+			out, err := tc.value(elem.Interface(), attribute)
+			if err != nil {
+				return nil, err
+			}
+			m[keyStr] = out
+		}
+		return m, nil
+
+	case reflect.Struct:
+		if hashset, ok := v.(set.HashedSet[string, any]); ok {
+			keys := make([]string, 0, len(hashset.M))
+			for k := range hashset.M {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			list := make([]any, len(keys))
+			for i, k := range keys {
+				out, err := tc.value(hashset.M[k], attribute)
+				if err != nil {
+					return nil, err
+				}
+				list[i] = out
+			}
+			return list, nil
+		}
+	}
+	return v, nil
+}
+
+// attributeFields renders a reference to another resource as an object whose fields are the given properties of the
+// referenced resource.
+func (tc *templateCompiler) attributeFields(v any, fields map[string]string) (any, error) {
+	id, ok := v.(construct.ResourceId)
+	if !ok {
+		return nil, fmt.Errorf("expected a resource reference, got %T", v)
+	}
+	obj := make(map[string]any, len(fields))
+	for field, property := range fields {
+		out, err := tc.propertyRef(construct.PropertyRef{Resource: id, Property: property})
+		if err != nil {
+			return nil, err
+		}
+		obj[field] = out
+	}
+	return obj, nil
+}
+
+// reference renders a reference to the resource, using `Ref` if attribute is empty, `Fn::Sub` if it contains
+// placeholders (see [templateCompiler.sub]) or pseudo parameters, or `Fn::GetAtt` otherwise.
+func (tc *templateCompiler) reference(id construct.ResourceId, attribute string) (any, error) {
+	if pseudo, ok := pseudoResources[id.QualifiedTypeName()]; ok {
+		r, err := tc.graph.Vertex(id)
+		if err != nil {
+			return nil, fmt.Errorf("could not get pseudo resource %s: %w", id, err)
+		}
+		return pseudo(r.Properties)
+	}
+	isSub := strings.Contains(attribute, "${")
+	if owner, ok := tc.embedded[id]; ok && !isSub {
+		return nil, fmt.Errorf("cannot reference %s since it is part of %s", id, owner)
+	}
+	logicalId, ok := tc.logicalIds[id]
+	if !ok {
+		return nil, fmt.Errorf("reference to %s which is not in the graph", id)
+	}
+	if attribute == "" {
+		return ref(logicalId), nil
+	}
+	if tc.parameters.Contains(id) {
+		return nil, fmt.Errorf("cannot get attribute %s of %s since it is a parameter", attribute, id)
+	}
+	if isSub {
+		return tc.sub(id, attribute)
+	}
+	return map[string]any{"Fn::GetAtt": []any{logicalId, attribute}}, nil
+}
+
+func (tc *templateCompiler) propertyRef(pref construct.PropertyRef) (any, error) {
+	if _, ok := pseudoResources[pref.Resource.QualifiedTypeName()]; ok {
+		return tc.reference(pref.Resource, "")
+	}
+	mapping, ok := resourceMappings[pref.Resource.QualifiedTypeName()]
+	if !ok && tc.parameters.Contains(pref.Resource) {
+		// The resource isn't part of the stack, so each of its properties is supplied as its own parameter
+		logicalId := tc.logicalIds[pref.Resource] + nonAlphanumeric.ReplaceAllString(strcase.ToCamel(pref.Property), "")
+		tc.template.Parameters[logicalId] = Parameter{
+			Type:        "String",
+			Description: fmt.Sprintf("Value for %s", pref),
+		}
+		return ref(logicalId), nil
+	}
+	if !ok {
+		return nil, fmt.Errorf("no CloudFormation mapping for %s", pref.Resource.QualifiedTypeName())
+	}
+	if isSecretParameter(mapping, pref.Property) && !tc.parameters.Contains(pref.Resource) {
+		return ref(tc.logicalIds[pref.Resource] + pref.Property), nil
+	}
+	if attribute, ok := mapping.Attributes[pref.Property]; ok {
+		return tc.reference(pref.Resource, attribute)
+	}
+	if derive, ok := mapping.DerivedAttributes[pref.Property]; ok && !tc.parameters.Contains(pref.Resource) {
+		r, err := tc.graph.Vertex(pref.Resource)
+		if err != nil {
+			return nil, err
+		}
+		attribute, err := derive(r)
+		if err != nil {
+			return nil, fmt.Errorf("could not derive %s: %w", pref, err)
+		}
+		return tc.reference(pref.Resource, attribute)
+	}
+	// Properties which are set in the graph (rather than computed on deployment) are used as-is
+	if r, err := tc.graph.Vertex(pref.Resource); err == nil {
+		if v, err := r.GetProperty(pref.Property); err == nil && v != nil {
+			return tc.value(v, "")
+		}
+	}
+	return nil, fmt.Errorf("no CloudFormation attribute for %s", pref)
+}
+
+// sub renders an attribute containing `${Name}` placeholders with `Fn::Sub`. Each placeholder is replaced by, in order
+// of precedence, the secret parameter or attribute of the resource with that name, the `Ref` of the resource
+// referenced by the resource's property of that name, or the value of that property. `${Property.Attribute}` is
+// replaced by the attribute of the resource referenced by the property. Pseudo parameters, such as `${AWS::Region}`,
+// are kept as-is. Resources which are part of another resource only have the latter placeholders.
+func (tc *templateCompiler) sub(id construct.ResourceId, attribute string) (any, error) {
+	mapping := resourceMappings[id.QualifiedTypeName()]
+	logicalId := tc.logicalIds[id]
+	r, err := tc.graph.Vertex(id)
+	if err != nil {
+		return nil, err
+	}
+	_, embedded := tc.embedded[id]
+	var errs error
+	str := subPlaceholder.ReplaceAllStringFunc(attribute, func(placeholder string) string {
+		name := subPlaceholder.FindStringSubmatch(placeholder)[1]
+		if isSecretParameter(mapping, name) && !embedded {
+			return "${" + logicalId + name + "}"
+		}
+		if attr, ok := mapping.Attributes[name]; ok && !embedded {
+			if subPlaceholder.MatchString(attr) {
+				errs = errors.Join(errs, fmt.Errorf("attribute %s of %s cannot be used within %q", name, id, attribute))
+				return placeholder
+			}
+			if attr == "" {
+				return "${" + logicalId + "}"
+			}
+			return "${" + logicalId + "." + attr + "}"
+		}
+		if refId, ok := r.Properties[name].(construct.ResourceId); ok {
+			refLogicalId, ok := tc.logicalIds[refId]
+			if !ok {
+				errs = errors.Join(errs, fmt.Errorf("%s#%s cannot be referenced within %q", id, name, attribute))
+				return placeholder
+			}
+			return "${" + refLogicalId + "}"
+		}
+		if property, attr, ok := strings.Cut(name, "."); ok {
+			if refId, ok := r.Properties[property].(construct.ResourceId); ok {
+				out, err := tc.subAttribute(refId, attr)
+				if err != nil {
+					errs = errors.Join(errs, fmt.Errorf("%s#%s cannot be referenced within %q: %w", id, name, attribute, err))
+					return placeholder
+				}
+				return out
+			}
+		}
+		if v, err := r.GetProperty(name); err == nil && v != nil {
+			switch v.(type) {
+			case string, bool, int, float64:
+				return fmt.Sprint(v)
+			}
+		}
+		errs = errors.Join(errs, fmt.Errorf("unknown placeholder %s in attribute %q of %s", placeholder, attribute, id))
+		return placeholder
+	})
+	if errs != nil {
+		return nil, errs
+	}
+	return map[string]any{"Fn::Sub": str}, nil
+}
+
+// subAttribute returns the `Fn::Sub` placeholder for the attribute of another resource.
+func (tc *templateCompiler) subAttribute(id construct.ResourceId, name string) (string, error) {
+	logicalId, ok := tc.logicalIds[id]
+	if !ok || tc.parameters.Contains(id) {
+		return "", fmt.Errorf("%s is not a resource in the stack", id)
+	}
+	attr, ok := resourceMappings[id.QualifiedTypeName()].Attributes[name]
+	switch {
+	case !ok:
+		return "", fmt.Errorf("no CloudFormation attribute %s for %s", name, id)
+	case subPlaceholder.MatchString(attr):
+		return "", fmt.Errorf("attribute %s of %s is itself substituted", name, id)
+	case attr == "":
+		return "${" + logicalId + "}", nil
+	}
+	return "${" + logicalId + "." + attr + "}", nil
+}
+
+func isSecretParameter(mapping resourceMapping, name string) bool {
+	for _, param := range mapping.SecretParameters {
+		if param == name {
+			return true
+		}
+	}
+	return false
+}
+
+func ref(logicalId string) map[string]any {
+	return map[string]any{"Ref": logicalId}
+}
+
+// setPath sets the value at the dot-separated path, creating any intermediate objects. An intermediate part
+// ending in `[N]` descends into the N-th object of the list at that key.
+func setPath(props map[string]any, path string, v any) {
+	parts := strings.Split(path, ".")
+	for _, part := range parts[:len(parts)-1] {
+		if key, idx, ok := listIndex(part); ok {
+			list, _ := props[key].([]any)
+			for len(list) <= idx {
+				list = append(list, make(map[string]any))
+			}
+			props[key] = list
+			next, ok := list[idx].(map[string]any)
+			if !ok {
+				next = make(map[string]any)
+				list[idx] = next
+			}
+			props = next
+			continue
+		}
+		next, ok := props[part].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			props[part] = next
+		}
+		props = next
+	}
+	last := parts[len(parts)-1]
+	if key, ok := strings.CutSuffix(last, "[]"); ok {
+		list, _ := props[key].([]any)
+		props[key] = append(list, v)
+		return
+	}
+	props[last] = v
+}
+
+// hasPath reports whether the dot-separated path (see [setPath]) is set.
+func hasPath(props map[string]any, path string) bool {
+	var v any = props
+	for _, part := range strings.Split(path, ".") {
+		part = strings.TrimSuffix(part, "[]")
+		if key, idx, ok := listIndex(part); ok {
+			obj, _ := v.(map[string]any)
+			list, _ := obj[key].([]any)
+			if idx >= len(list) {
+				return false
+			}
+			v = list[idx]
+			continue
+		}
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return false
+		}
+		if v, ok = obj[part]; !ok {
+			return false
+		}
+	}
+	return v != nil
+}
+
+// listIndex splits a path part of the form `key[N]` into its key and index.
+func listIndex(part string) (string, int, bool) {
+	key, rest, ok := strings.Cut(part, "[")
+	if !ok {
+		return "", 0, false
+	}
+	idx, err := strconv.Atoi(strings.TrimSuffix(rest, "]"))
+	if err != nil || !strings.HasSuffix(rest, "]") {
+		return "", 0, false
+	}
+	return key, idx, true
+}
+
+// renameFields renames the keys of an object, or each object in a list. Dot-separated keys rename the keys of nested
+// objects.
+func renameFields(v any, fields map[string]string) any {
+	switch v := v.(type) {
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = renameFields(elem, fields)
+		}
+		return out
+
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, elem := range v {
+			nested := make(map[string]string)
+			for field, renamed := range fields {
+				if sub, ok := strings.CutPrefix(field, k+"."); ok {
+					nested[sub] = renamed
+				}
+			}
+			if len(nested) > 0 {
+				elem = renameFields(elem, nested)
+			}
+			if renamed, ok := fields[k]; ok {
+				k = renamed
+			}
+			out[k] = elem
+		}
+		return out
+	}
+	return v
+}
+
+// copyValue deep copies the objects and lists within v, so that setting paths within the copy does not modify v.
+func copyValue(v any) any {
+	switch v := v.(type) {
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = copyValue(elem)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, elem := range v {
+			out[k] = copyValue(elem)
+		}
+		return out
+	}
+	return v
+}
+
+func containsSelfRef(v any) bool {
+	switch v := v.(type) {
+	case selfRef:
+		return true
+	case []any:
+		for _, elem := range v {
+			if containsSelfRef(elem) {
+				return true
+			}
+		}
+	case map[string]any:
+		for _, elem := range v {
+			if containsSelfRef(elem) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func replaceSelfRef(v any, replacement any) any {
+	switch v := v.(type) {
+	case selfRef:
+		return replacement
+	case []any:
+		out := make([]any, len(v))
+		for i, elem := range v {
+			out[i] = replaceSelfRef(elem, replacement)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, elem := range v {
+			out[k] = replaceSelfRef(elem, replacement)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,1136 @@
+package cloudformation
+
+import (
+	"testing"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	"github.com/klothoplatform/klotho/pkg/construct2/graphtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNewTemplate(t *testing.T) {
+	id := func(s string) construct.ResourceId {
+		return graphtest.ParseId(t, s)
+	}
+	tests := []struct {
+		name     string
+		elements []any
+		want     string
+		wantErr  string
+	}{
+		{
+			name: "refs, attributes and depends on",
+			elements: []any{
+				&construct.Resource{
+					ID: id("aws:lambda_function:fn"),
+					Properties: construct.Properties{
+						"ExecutionRole": id("aws:iam_role:role"),
+						"Timeout":       30,
+						"EnvironmentVariables": map[string]any{
+							"QUEUE_ARN": construct.PropertyRef{Resource: id("aws:sqs_queue:queue"), Property: "Arn"},
+						},
+					},
+				},
+				&construct.Resource{ID: id("aws:iam_role:role")},
+				&construct.Resource{
+					ID:         id("aws:sqs_queue:queue"),
+					Properties: construct.Properties{"Tags": map[string]any{"b": "2", "a": "1"}},
+				},
+				"aws:lambda_function:fn -> aws:iam_role:role",
+				"aws:lambda_function:fn -> aws:sqs_queue:queue",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  IamRoleRole:
+    Type: AWS::IAM::Role
+  LambdaFunctionFn:
+    Type: AWS::Lambda::Function
+    DependsOn: [IamRoleRole, SqsQueueQueue]
+    Properties:
+      FunctionName: fn
+      PackageType: Image
+      Role: {Fn::GetAtt: [IamRoleRole, Arn]}
+      Timeout: 30
+      Environment:
+        Variables:
+          QUEUE_ARN: {Fn::GetAtt: [SqsQueueQueue, Arn]}
+  SqsQueueQueue:
+    Type: AWS::SQS::Queue
+    Properties:
+      Tags:
+        - {Key: a, Value: "1"}
+        - {Key: b, Value: "2"}
+`,
+		},
+		{
+			name: "imported resources are parameters",
+			elements: []any{
+				&construct.Resource{ID: id("aws:vpc:vpc"), Imported: true},
+				&construct.Resource{
+					ID: id("aws:subnet:subnet"),
+					Properties: construct.Properties{
+						"Vpc":              id("aws:vpc:vpc"),
+						"AvailabilityZone": id("aws:availability_zone:az1"),
+					},
+				},
+				&construct.Resource{ID: id("aws:availability_zone:az1"), Properties: construct.Properties{"Index": 1}},
+				"aws:subnet:subnet -> aws:vpc:vpc",
+				"aws:subnet:subnet -> aws:availability_zone:az1",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  VpcVpc:
+    Type: AWS::EC2::VPC::Id
+    Description: Value for aws:vpc:vpc
+Resources:
+  SubnetSubnet:
+    Type: AWS::EC2::Subnet
+    Properties:
+      AvailabilityZone: {Fn::Select: [1, {Fn::GetAZs: ""}]}
+      VpcId: {Ref: VpcVpc}
+`,
+		},
+		{
+			name: "child resources",
+			elements: []any{
+				&construct.Resource{
+					ID: id("aws:route_table:rt"),
+					Properties: construct.Properties{
+						"Routes": []any{
+							map[string]any{"CidrBlock": "0.0.0.0/0", "Gateway": id("aws:internet_gateway:igw")},
+						},
+					},
+				},
+				&construct.Resource{ID: id("aws:internet_gateway:igw")},
+				"aws:route_table:rt -> aws:internet_gateway:igw",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  InternetGatewayIgw:
+    Type: AWS::EC2::InternetGateway
+  RouteTableRt:
+    Type: AWS::EC2::RouteTable
+    DependsOn: [InternetGatewayIgw]
+  RouteTableRtRoutes0:
+    Type: AWS::EC2::Route
+    Properties:
+      RouteTableId: {Ref: RouteTableRt}
+      DestinationCidrBlock: 0.0.0.0/0
+      GatewayId: {Ref: InternetGatewayIgw}
+`,
+		},
+		{
+			name: "peering routes and flow log of imported vpc",
+			elements: []any{
+				&construct.Resource{ID: id("aws:vpc:shared"), Imported: true},
+				&construct.Resource{ID: id("aws:vpc:spoke")},
+				&construct.Resource{
+					ID: id("aws:vpc_peering_connection:pcx"),
+					Properties: construct.Properties{
+						"Vpc":     id("aws:vpc:spoke"),
+						"PeerVpc": id("aws:vpc:shared"),
+					},
+				},
+				&construct.Resource{
+					ID: id("aws:route_table:rt"),
+					Properties: construct.Properties{
+						"Routes": []any{
+							map[string]any{"CidrBlock": "10.1.0.0/16", "VpcPeeringConnection": id("aws:vpc_peering_connection:pcx")},
+						},
+					},
+				},
+				&construct.Resource{ID: id("aws:s3_bucket:logs")},
+				&construct.Resource{
+					ID: id("aws:vpc_flow_log:shared"),
+					Properties: construct.Properties{
+						"Vpc":                id("aws:vpc:shared"),
+						"LogDestinationType": "s3",
+						"S3Bucket":           id("aws:s3_bucket:logs"),
+						"TrafficType":        "ALL",
+					},
+				},
+				"aws:vpc_peering_connection:pcx -> aws:vpc:spoke",
+				"aws:vpc_peering_connection:pcx -> aws:vpc:shared",
+				"aws:route_table:rt -> aws:vpc_peering_connection:pcx",
+				"aws:vpc_flow_log:shared -> aws:vpc:shared",
+				"aws:vpc_flow_log:shared -> aws:s3_bucket:logs",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  VpcShared:
+    Type: AWS::EC2::VPC::Id
+    Description: Value for aws:vpc:shared
+Resources:
+  RouteTableRt:
+    Type: AWS::EC2::RouteTable
+    DependsOn: [VpcPeeringConnectionPcx]
+  RouteTableRtRoutes0:
+    Type: AWS::EC2::Route
+    Properties:
+      RouteTableId: {Ref: RouteTableRt}
+      DestinationCidrBlock: 10.1.0.0/16
+      VpcPeeringConnectionId: {Ref: VpcPeeringConnectionPcx}
+  S3BucketLogs:
+    Type: AWS::S3::Bucket
+  VpcFlowLogShared:
+    Type: AWS::EC2::FlowLog
+    DependsOn: [S3BucketLogs]
+    Properties:
+      ResourceType: VPC
+      ResourceId: {Ref: VpcShared}
+      LogDestinationType: s3
+      LogDestination: {Fn::GetAtt: [S3BucketLogs, Arn]}
+      TrafficType: ALL
+  VpcPeeringConnectionPcx:
+    Type: AWS::EC2::VPCPeeringConnection
+    DependsOn: [VpcSpoke]
+    Properties:
+      VpcId: {Ref: VpcSpoke}
+      PeerVpcId: {Ref: VpcShared}
+  VpcSpoke:
+    Type: AWS::EC2::VPC
+`,
+		},
+		{
+			name: "converted properties can be omitted",
+			elements: []any{
+				&construct.Resource{
+					ID: id("aws:dynamodb_table:streamed"),
+					Properties: construct.Properties{
+						"StreamSpecification": map[string]any{"StreamEnabled": true, "StreamViewType": "KEYS_ONLY"},
+					},
+				},
+				&construct.Resource{
+					ID: id("aws:dynamodb_table:plain"),
+					Properties: construct.Properties{
+						"StreamSpecification": map[string]any{"StreamEnabled": false},
+					},
+				},
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  DynamodbTablePlain:
+    Type: AWS::DynamoDB::Table
+    Properties:
+      TableName: plain
+  DynamodbTableStreamed:
+    Type: AWS::DynamoDB::Table
+    Properties:
+      TableName: streamed
+      StreamSpecification:
+        StreamViewType: KEYS_ONLY
+`,
+		},
+		{
+			name: "list element paths and key policy",
+			elements: []any{
+				&construct.Resource{
+					ID: id("aws:s3_bucket:bucket"),
+					Properties: construct.Properties{
+						"KmsKey":       id("aws:kms_key:key"),
+						"SSEAlgorithm": "aws:kms",
+					},
+				},
+				&construct.Resource{
+					ID: id("aws:kms_key:key"),
+					Properties: construct.Properties{
+						"KeyPolicy":         map[string]any{"Version": "2012-10-17"},
+						"ServicePrincipals": []any{"logs", "logs"},
+					},
+				},
+				"aws:s3_bucket:bucket -> aws:kms_key:key",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  KmsKeyKey:
+    Type: AWS::KMS::Key
+    Properties:
+      KeyPolicy:
+        Version: "2012-10-17"
+        Statement:
+          - Effect: Allow
+            Principal: {AWS: {Fn::Sub: "arn:${AWS::Partition}:iam::${AWS::AccountId}:root"}}
+            Action: kms:*
+            Resource: "*"
+          - Effect: Allow
+            Principal:
+              Service:
+                - {Fn::Sub: "logs.${AWS::Region}.amazonaws.com"}
+            Action: [kms:Decrypt*, kms:Describe*, kms:Encrypt*, kms:GenerateDataKey*, kms:ReEncrypt*]
+            Resource: "*"
+  S3BucketBucket:
+    Type: AWS::S3::Bucket
+    DependsOn: [KmsKeyKey]
+    Properties:
+      BucketEncryption:
+        ServerSideEncryptionConfiguration:
+          - ServerSideEncryptionByDefault:
+              KMSMasterKeyID: {Fn::GetAtt: [KmsKeyKey, Arn]}
+              SSEAlgorithm: aws:kms
+`,
+		},
+		{
+			name: "zip function with layer",
+			elements: []any{
+				&construct.Resource{
+					ID:         id("aws:lambda_layer_version:deps"),
+					Properties: construct.Properties{"Code": "./deps", "CompatibleRuntimes": []any{"python3.12"}},
+				},
+				&construct.Resource{
+					ID: id("aws:lambda_function:fn"),
+					Properties: construct.Properties{
+						"PackageType": "Zip",
+						"Code":        "./fn",
+						"Handler":     "main.handler",
+						"Runtime":     "python3.12",
+						"Layers":      []any{id("aws:lambda_layer_version:deps")},
+					},
+				},
+				"aws:lambda_function:fn -> aws:lambda_layer_version:deps",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  LambdaFunctionFn:
+    Type: AWS::Lambda::Function
+    DependsOn: [LambdaLayerVersionDeps]
+    Properties:
+      FunctionName: fn
+      Code: ./fn
+      Handler: main.handler
+      Layers: [{Ref: LambdaLayerVersionDeps}]
+      PackageType: Zip
+      Runtime: python3.12
+  LambdaLayerVersionDeps:
+    Type: AWS::Lambda::LayerVersion
+    Properties:
+      LayerName: deps
+      CompatibleRuntimes: [python3.12]
+      Content: ./deps
+`,
+		},
+		{
+			name: "alarm dimensions and dashboard body",
+			elements: []any{
+				&construct.Resource{ID: id("aws:sqs_queue:queue")},
+				&construct.Resource{ID: id("aws:sns_topic:topic")},
+				&construct.Resource{
+					ID: id("aws:cloudwatch_alarm:age"),
+					Properties: construct.Properties{
+						"MetricName":   "ApproximateAgeOfOldestMessage",
+						"Namespace":    "AWS/SQS",
+						"Threshold":    300,
+						"AlarmActions": []any{id("aws:sns_topic:topic")},
+						"Dimensions": map[string]any{
+							"QueueName": construct.PropertyRef{Resource: id("aws:sqs_queue:queue"), Property: "QueueName"},
+						},
+					},
+				},
+				&construct.Resource{
+					ID: id("aws:cloudwatch_dashboard:oncall"),
+					Properties: construct.Properties{
+						"Widgets": []any{
+							map[string]any{
+								"Title":  "queue",
+								"Alarms": []any{id("aws:cloudwatch_alarm:age")},
+								"Width":  12,
+								"Height": 6,
+							},
+						},
+					},
+				},
+				"aws:cloudwatch_alarm:age -> aws:sqs_queue:queue",
+				"aws:cloudwatch_alarm:age -> aws:sns_topic:topic",
+				"aws:cloudwatch_dashboard:oncall -> aws:cloudwatch_alarm:age",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  CloudwatchAlarmAge:
+    Type: AWS::CloudWatch::Alarm
+    DependsOn: [SnsTopicTopic, SqsQueueQueue]
+    Properties:
+      AlarmName: age
+      AlarmActions: [{Ref: SnsTopicTopic}]
+      Dimensions:
+        - Name: QueueName
+          Value: {Fn::GetAtt: [SqsQueueQueue, QueueName]}
+      MetricName: ApproximateAgeOfOldestMessage
+      Namespace: AWS/SQS
+      Threshold: 300
+  CloudwatchDashboardOncall:
+    Type: AWS::CloudWatch::Dashboard
+    DependsOn: [CloudwatchAlarmAge]
+    Properties:
+      DashboardName: oncall
+      DashboardBody:
+        Fn::Join:
+          - ""
+          - - '{"widgets":['
+            - '{"type":"alarm","width":12,"height":6,"properties":{"title":"queue","alarms":['
+            - '"'
+            - {Fn::GetAtt: [CloudwatchAlarmAge, Arn]}
+            - '"'
+            - "]}}"
+            - "]}"
+  SnsTopicTopic:
+    Type: AWS::SNS::Topic
+    Properties:
+      TopicName: topic
+  SqsQueueQueue:
+    Type: AWS::SQS::Queue
+`,
+		},
+		{
+			name: "attributes derived with sub",
+			elements: []any{
+				&construct.Resource{ID: id("aws:s3_bucket:bucket")},
+				&construct.Resource{
+					ID: id("aws:lambda_function:fn"),
+					Properties: construct.Properties{
+						"EnvironmentVariables": map[string]any{
+							"OBJECTS": construct.PropertyRef{Resource: id("aws:s3_bucket:bucket"), Property: "AllBucketDirectory"},
+						},
+					},
+				},
+				"aws:lambda_function:fn -> aws:s3_bucket:bucket",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  LambdaFunctionFn:
+    Type: AWS::Lambda::Function
+    DependsOn: [S3BucketBucket]
+    Properties:
+      FunctionName: fn
+      PackageType: Image
+      Environment:
+        Variables:
+          OBJECTS: {Fn::Sub: "${S3BucketBucket.Arn}/*"}
+  S3BucketBucket:
+    Type: AWS::S3::Bucket
+`,
+		},
+		{
+			name: "stage arn",
+			elements: []any{
+				&construct.Resource{ID: id("aws:rest_api:api")},
+				&construct.Resource{
+					ID:         id("aws:api_stage:api:prod"),
+					Properties: construct.Properties{"RestApi": id("aws:rest_api:api"), "StageName": "prod"},
+				},
+				&construct.Resource{
+					ID: id("aws:lambda_function:fn"),
+					Properties: construct.Properties{
+						"EnvironmentVariables": map[string]any{
+							"STAGE": construct.PropertyRef{Resource: id("aws:api_stage:api:prod"), Property: "Arn"},
+						},
+					},
+				},
+				"aws:api_stage:api:prod -> aws:rest_api:api",
+				"aws:lambda_function:fn -> aws:api_stage:api:prod",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  RestApiApi:
+    Type: AWS::ApiGateway::RestApi
+    Properties:
+      Name: api
+  ApiStageApiProd:
+    Type: AWS::ApiGateway::Stage
+    DependsOn: [RestApiApi]
+    Properties:
+      RestApiId: {Ref: RestApiApi}
+      StageName: prod
+  LambdaFunctionFn:
+    Type: AWS::Lambda::Function
+    DependsOn: [ApiStageApiProd]
+    Properties:
+      FunctionName: fn
+      PackageType: Image
+      Environment:
+        Variables:
+          STAGE: {Fn::Sub: "arn:${AWS::Partition}:apigateway:${AWS::Region}::/restapis/${RestApiApi}/stages/${ApiStageApiProd}"}
+`,
+		},
+		{
+			name: "secret values and generated credentials",
+			elements: []any{
+				&construct.Resource{
+					ID:         id("aws:rds_instance:db"),
+					Properties: construct.Properties{"Engine": "postgres", "DatabaseName": "main"},
+				},
+				&construct.Resource{ID: id("aws:secret:creds")},
+				&construct.Resource{
+					ID: id("aws:secret_version:creds-version"),
+					Properties: construct.Properties{
+						"Secret":  id("aws:secret:creds"),
+						"Content": construct.PropertyRef{Resource: id("aws:rds_instance:db"), Property: "CredentialsSecretValue"},
+					},
+				},
+				&construct.Resource{ID: id("aws:secret:api-key")},
+				&construct.Resource{
+					ID:         id("aws:secret_version:api-key-version"),
+					Properties: construct.Properties{"Secret": id("aws:secret:api-key")},
+				},
+				"aws:secret_version:creds-version -> aws:secret:creds",
+				"aws:secret_version:creds-version -> aws:rds_instance:db",
+				"aws:secret_version:api-key-version -> aws:secret:api-key",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  RdsInstanceDbUsername:
+    Type: String
+    Description: Value for aws:rds_instance:db#Username
+    NoEcho: true
+  RdsInstanceDbPassword:
+    Type: String
+    Description: Value for aws:rds_instance:db#Password
+    NoEcho: true
+  SecretApiKeyContent:
+    Type: String
+    Description: Value for aws:secret_version:api-key-version#Content
+    NoEcho: true
+Resources:
+  RdsInstanceDb:
+    Type: AWS::RDS::DBInstance
+    Properties:
+      DBName: main
+      Engine: postgres
+      MasterUsername: {Ref: RdsInstanceDbUsername}
+      MasterUserPassword: {Ref: RdsInstanceDbPassword}
+  SecretApiKey:
+    Type: AWS::SecretsManager::Secret
+    Properties:
+      SecretString: {Ref: SecretApiKeyContent}
+  SecretCreds:
+    Type: AWS::SecretsManager::Secret
+    DependsOn: [RdsInstanceDb]
+    Properties:
+      SecretString:
+        Fn::Sub: '{"username":"${RdsInstanceDbUsername}","password":"${RdsInstanceDbPassword}","engine":"postgres","host":"${RdsInstanceDb.Endpoint.Address}","port":${RdsInstanceDb.Endpoint.Port},"dbname":"main"}'
+`,
+		},
+		{
+			name: "security group rule types",
+			elements: []any{
+				&construct.Resource{ID: id("aws:security_group:sg")},
+				&construct.Resource{
+					ID: id("aws:security_group_rule:https-in"),
+					Properties: construct.Properties{
+						"Type":            "ingress",
+						"CidrBlocks":      []any{"10.0.0.0/16"},
+						"FromPort":        443,
+						"ToPort":          443,
+						"Protocol":        "tcp",
+						"SecurityGroupId": construct.PropertyRef{Resource: id("aws:security_group:sg"), Property: "Id"},
+					},
+				},
+				&construct.Resource{
+					ID: id("aws:security_group_rule:all-out"),
+					Properties: construct.Properties{
+						"Type":            "egress",
+						"CidrBlocks":      []any{"0.0.0.0/0"},
+						"FromPort":        0,
+						"ToPort":          0,
+						"Protocol":        "-1",
+						"SecurityGroupId": construct.PropertyRef{Resource: id("aws:security_group:sg"), Property: "Id"},
+					},
+				},
+				"aws:security_group_rule:https-in -> aws:security_group:sg",
+				"aws:security_group_rule:all-out -> aws:security_group:sg",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  SecurityGroupRuleAllOut:
+    Type: AWS::EC2::SecurityGroupEgress
+    DependsOn: [SecurityGroupSg]
+    Properties:
+      CidrIp: 0.0.0.0/0
+      FromPort: 0
+      GroupId: {Fn::GetAtt: [SecurityGroupSg, GroupId]}
+      IpProtocol: "-1"
+      ToPort: 0
+  SecurityGroupRuleHttpsIn:
+    Type: AWS::EC2::SecurityGroupIngress
+    DependsOn: [SecurityGroupSg]
+    Properties:
+      CidrIp: 10.0.0.0/16
+      FromPort: 443
+      GroupId: {Fn::GetAtt: [SecurityGroupSg, GroupId]}
+      IpProtocol: tcp
+      ToPort: 443
+  SecurityGroupSg:
+    Type: AWS::EC2::SecurityGroup
+    Properties:
+      GroupName: sg
+      GroupDescription: Managed by Klotho
+`,
+		},
+		{
+			name: "security group rule with several CIDR blocks",
+			elements: []any{
+				&construct.Resource{
+					ID:         id("aws:security_group_rule:rule"),
+					Properties: construct.Properties{"CidrBlocks": []any{"10.0.0.0/16", "10.1.0.0/16"}},
+				},
+			},
+			wantErr: "security group rules support a single CIDR block, got 2",
+		},
+		{
+			name: "policy attachments are part of their role",
+			elements: []any{
+				&construct.Resource{
+					ID:         id("aws:iam_role:role"),
+					Properties: construct.Properties{"ManagedPolicies": []any{"arn:aws:iam::aws:policy/ReadOnlyAccess"}},
+				},
+				&construct.Resource{ID: id("aws:iam_policy:policy")},
+				&construct.Resource{
+					ID: id("aws:iam_role_policy_attachment:attach"),
+					Properties: construct.Properties{
+						"Role":   id("aws:iam_role:role"),
+						"Policy": id("aws:iam_policy:policy"),
+					},
+				},
+				"aws:iam_role:role -> aws:iam_role_policy_attachment:attach",
+				"aws:iam_role_policy_attachment:attach -> aws:iam_policy:policy",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  IamPolicyPolicy:
+    Type: AWS::IAM::ManagedPolicy
+    Properties:
+      ManagedPolicyName: policy
+  IamRoleRole:
+    Type: AWS::IAM::Role
+    DependsOn: [IamPolicyPolicy]
+    Properties:
+      ManagedPolicyArns:
+        - arn:aws:iam::aws:policy/ReadOnlyAccess
+        - {Ref: IamPolicyPolicy}
+`,
+		},
+		{
+			name: "private integration uri derived from its route",
+			elements: []any{
+				&construct.Resource{ID: id("aws:rest_api:api")},
+				&construct.Resource{ID: id("aws:load_balancer:lb")},
+				&construct.Resource{ID: id("aws:vpc_link:link"), Properties: construct.Properties{"Target": id("aws:load_balancer:lb")}},
+				&construct.Resource{
+					ID:         id("aws:api_method:api:items"),
+					Properties: construct.Properties{"RestApi": id("aws:rest_api:api"), "HttpMethod": "ANY"},
+				},
+				&construct.Resource{
+					ID: id("aws:api_integration:api:items"),
+					Properties: construct.Properties{
+						"Method":         id("aws:api_method:api:items"),
+						"Target":         id("aws:load_balancer:lb"),
+						"Route":          "/items/{id+}",
+						"ConnectionType": "VPC_LINK",
+						"VpcLink":        id("aws:vpc_link:link"),
+						"Uri":            construct.PropertyRef{Resource: id("aws:api_integration:api:items"), Property: "LbUri"},
+					},
+				},
+				"aws:vpc_link:link -> aws:load_balancer:lb",
+				"aws:api_method:api:items -> aws:rest_api:api",
+				"aws:api_method:api:items -> aws:api_integration:api:items",
+				"aws:api_integration:api:items -> aws:vpc_link:link",
+				"aws:api_integration:api:items -> aws:load_balancer:lb",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  ApiMethodApiItems:
+    Type: AWS::ApiGateway::Method
+    DependsOn: [LoadBalancerLb, RestApiApi, VpcLinkLink]
+    Properties:
+      HttpMethod: ANY
+      ResourceId: {Fn::GetAtt: [RestApiApi, RootResourceId]}
+      RestApiId: {Ref: RestApiApi}
+      Integration:
+        ConnectionId: {Ref: VpcLinkLink}
+        ConnectionType: VPC_LINK
+        Uri: {Fn::Sub: "http://${LoadBalancerLb.DNSName}/items/{id}"}
+  LoadBalancerLb:
+    Type: AWS::ElasticLoadBalancingV2::LoadBalancer
+  RestApiApi:
+    Type: AWS::ApiGateway::RestApi
+    Properties:
+      Name: api
+  VpcLinkLink:
+    Type: AWS::ApiGateway::VpcLink
+    DependsOn: [LoadBalancerLb]
+    Properties:
+      Name: link
+      TargetArns: [{Ref: LoadBalancerLb}]
+`,
+		},
+		{
+			name: "rds storage encrypted with kms key",
+			elements: []any{
+				&construct.Resource{ID: id("aws:kms_key:key")},
+				&construct.Resource{
+					ID:         id("aws:rds_instance:db"),
+					Properties: construct.Properties{"Engine": "postgres", "KmsKey": id("aws:kms_key:key")},
+				},
+				"aws:rds_instance:db -> aws:kms_key:key",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  RdsInstanceDbUsername:
+    Type: String
+    Description: Value for aws:rds_instance:db#Username
+    NoEcho: true
+  RdsInstanceDbPassword:
+    Type: String
+    Description: Value for aws:rds_instance:db#Password
+    NoEcho: true
+Resources:
+  KmsKeyKey:
+    Type: AWS::KMS::Key
+  RdsInstanceDb:
+    Type: AWS::RDS::DBInstance
+    DependsOn: [KmsKeyKey]
+    Properties:
+      Engine: postgres
+      KmsKeyId: {Fn::GetAtt: [KmsKeyKey, Arn]}
+      MasterUsername: {Ref: RdsInstanceDbUsername}
+      MasterUserPassword: {Ref: RdsInstanceDbPassword}
+      StorageEncrypted: true
+`,
+		},
+		{
+			name: "cognito authorizers and listener actions",
+			elements: []any{
+				&construct.Resource{ID: id("aws:rest_api:api")},
+				&construct.Resource{ID: id("aws:cognito_user_pool:users")},
+				&construct.Resource{
+					ID:         id("aws:cognito_user_pool_client:web"),
+					Properties: construct.Properties{"UserPool": id("aws:cognito_user_pool:users")},
+				},
+				&construct.Resource{
+					ID: id("aws:api_authorizer:api:users"),
+					Properties: construct.Properties{
+						"RestApi":        id("aws:rest_api:api"),
+						"Type":           "COGNITO_USER_POOLS",
+						"IdentitySource": "method.request.header.Authorization",
+						"UserPools":      []any{id("aws:cognito_user_pool:users")},
+					},
+				},
+				&construct.Resource{
+					ID: id("aws:api_method:api:users"),
+					Properties: construct.Properties{
+						"RestApi":       id("aws:rest_api:api"),
+						"HttpMethod":    "ANY",
+						"Authorization": "COGNITO_USER_POOLS",
+						"Authorizer":    id("aws:api_authorizer:api:users"),
+					},
+				},
+				&construct.Resource{ID: id("aws:load_balancer:lb")},
+				&construct.Resource{
+					ID: id("aws:load_balancer_listener:lb:https"),
+					Properties: construct.Properties{
+						"LoadBalancer": id("aws:load_balancer:lb"),
+						"DefaultActions": []any{
+							map[string]any{
+								"Type": "authenticate-cognito",
+								"AuthenticateCognito": map[string]any{
+									"UserPool":       id("aws:cognito_user_pool:users"),
+									"UserPoolClient": id("aws:cognito_user_pool_client:web"),
+								},
+							},
+						},
+					},
+				},
+				"aws:cognito_user_pool_client:web -> aws:cognito_user_pool:users",
+				"aws:api_authorizer:api:users -> aws:rest_api:api",
+				"aws:api_authorizer:api:users -> aws:cognito_user_pool:users",
+				"aws:api_method:api:users -> aws:rest_api:api",
+				"aws:api_method:api:users -> aws:api_authorizer:api:users",
+				"aws:load_balancer_listener:lb:https -> aws:load_balancer:lb",
+				"aws:load_balancer_listener:lb:https -> aws:cognito_user_pool:users",
+				"aws:load_balancer_listener:lb:https -> aws:cognito_user_pool_client:web",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  RestApiApi:
+    Type: AWS::ApiGateway::RestApi
+    Properties:
+      Name: api
+  CognitoUserPoolUsers:
+    Type: AWS::Cognito::UserPool
+    Properties:
+      UserPoolName: users
+  CognitoUserPoolClientWeb:
+    Type: AWS::Cognito::UserPoolClient
+    DependsOn: [CognitoUserPoolUsers]
+    Properties:
+      ClientName: web
+      UserPoolId: {Ref: CognitoUserPoolUsers}
+  ApiAuthorizerApiUsers:
+    Type: AWS::ApiGateway::Authorizer
+    DependsOn: [CognitoUserPoolUsers, RestApiApi]
+    Properties:
+      Name: users
+      RestApiId: {Ref: RestApiApi}
+      Type: COGNITO_USER_POOLS
+      IdentitySource: method.request.header.Authorization
+      ProviderARNs:
+        - {Fn::GetAtt: [CognitoUserPoolUsers, Arn]}
+  ApiMethodApiUsers:
+    Type: AWS::ApiGateway::Method
+    DependsOn: [ApiAuthorizerApiUsers, RestApiApi]
+    Properties:
+      RestApiId: {Ref: RestApiApi}
+      ResourceId: {Fn::GetAtt: [RestApiApi, RootResourceId]}
+      HttpMethod: ANY
+      AuthorizationType: COGNITO_USER_POOLS
+      AuthorizerId: {Ref: ApiAuthorizerApiUsers}
+  LoadBalancerLb:
+    Type: AWS::ElasticLoadBalancingV2::LoadBalancer
+  LoadBalancerListenerLbHttps:
+    Type: AWS::ElasticLoadBalancingV2::Listener
+    DependsOn: [CognitoUserPoolClientWeb, CognitoUserPoolUsers, LoadBalancerLb]
+    Properties:
+      LoadBalancerArn: {Ref: LoadBalancerLb}
+      DefaultActions:
+        - Type: authenticate-cognito
+          AuthenticateCognitoConfig:
+            UserPoolArn: {Fn::GetAtt: [CognitoUserPoolUsers, Arn]}
+            UserPoolClientId: {Ref: CognitoUserPoolClientWeb}
+`,
+		},
+		{
+			name: "container insights and capacity provider strategy",
+			elements: []any{
+				&construct.Resource{
+					ID:         id("aws:ecs_cluster:cluster"),
+					Properties: construct.Properties{"ContainerInsights": true},
+				},
+				&construct.Resource{
+					ID: id("aws:ecs_service:worker"),
+					Properties: construct.Properties{
+						"Cluster":    id("aws:ecs_cluster:cluster"),
+						"LaunchType": "FARGATE",
+						"CapacityProviderStrategy": []any{
+							map[string]any{"CapacityProvider": "FARGATE_SPOT", "Weight": 1},
+						},
+					},
+				},
+				"aws:ecs_service:worker -> aws:ecs_cluster:cluster",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  EcsClusterCluster:
+    Type: AWS::ECS::Cluster
+    Properties:
+      ClusterName: cluster
+      ClusterSettings:
+        - Name: containerInsights
+          Value: enabled
+  EcsServiceWorker:
+    Type: AWS::ECS::Service
+    DependsOn: [EcsClusterCluster]
+    Properties:
+      ServiceName: worker
+      Cluster: {Ref: EcsClusterCluster}
+      CapacityProviderStrategy:
+        - CapacityProvider: FARGATE_SPOT
+          Weight: 1
+`,
+		},
+		{
+			name: "fifo topic name suffix",
+			elements: []any{
+				&construct.Resource{
+					ID:         id("aws:sns_topic:orders"),
+					Properties: construct.Properties{"FifoTopic": true},
+				},
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  SnsTopicOrders:
+    Type: AWS::SNS::Topic
+    Properties:
+      FifoTopic: true
+      TopicName: orders.fifo
+`,
+		},
+		{
+			name: "certificate validation is part of its certificate",
+			elements: []any{
+				&construct.Resource{
+					ID:         id("aws:acm_certificate:cert"),
+					Properties: construct.Properties{"DomainName": "example.com", "ValidationMethod": "DNS"},
+				},
+				&construct.Resource{
+					ID:         id("aws:acm_certificate_validation:cert"),
+					Properties: construct.Properties{"Certificate": id("aws:acm_certificate:cert")},
+				},
+				&construct.Resource{
+					ID: id("aws:cloudfront_distribution:cdn"),
+					Properties: construct.Properties{
+						"Aliases":                      []any{"example.com"},
+						"Certificate":                  id("aws:acm_certificate:cert"),
+						"CertificateValidation":        id("aws:acm_certificate_validation:cert"),
+						"CloudfrontDefaultCertificate": true,
+						"Enabled":                      true,
+					},
+				},
+				"aws:acm_certificate_validation:cert -> aws:acm_certificate:cert",
+				"aws:cloudfront_distribution:cdn -> aws:acm_certificate:cert",
+				"aws:cloudfront_distribution:cdn -> aws:acm_certificate_validation:cert",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  AcmCertificateCert:
+    Type: AWS::CertificateManager::Certificate
+    Properties:
+      DomainName: example.com
+      ValidationMethod: DNS
+  CloudfrontDistributionCdn:
+    Type: AWS::CloudFront::Distribution
+    DependsOn: [AcmCertificateCert]
+    Properties:
+      DistributionConfig:
+        Aliases: [example.com]
+        Enabled: true
+        ViewerCertificate:
+          AcmCertificateArn: {Ref: AcmCertificateCert}
+          SslSupportMethod: sni-only
+`,
+		},
+		{
+			name: "ecs event target is part of its rule",
+			elements: []any{
+				&construct.Resource{
+					ID:         id("aws:event_rule:nightly"),
+					Properties: construct.Properties{"ScheduleExpression": "cron(0 3 * * ? *)"},
+				},
+				&construct.Resource{ID: id("aws:ecs_cluster:cluster")},
+				&construct.Resource{ID: id("aws:ecs_task_definition:job")},
+				&construct.Resource{ID: id("aws:iam_role:nightly-job-role")},
+				&construct.Resource{
+					ID: id("aws:event_target:nightly-job"),
+					Properties: construct.Properties{
+						"Rule":           id("aws:event_rule:nightly"),
+						"Arn":            construct.PropertyRef{Resource: id("aws:ecs_cluster:cluster"), Property: "Arn"},
+						"Cluster":        id("aws:ecs_cluster:cluster"),
+						"Role":           id("aws:iam_role:nightly-job-role"),
+						"TaskDefinition": id("aws:ecs_task_definition:job"),
+						"TaskCount":      1,
+						"LaunchType":     "FARGATE",
+						"AssignPublicIp": false,
+					},
+				},
+				"aws:event_rule:nightly -> aws:event_target:nightly-job",
+				"aws:event_target:nightly-job -> aws:ecs_cluster:cluster",
+				"aws:event_target:nightly-job -> aws:iam_role:nightly-job-role",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  EcsClusterCluster:
+    Type: AWS::ECS::Cluster
+    Properties:
+      ClusterName: cluster
+  EcsTaskDefinitionJob:
+    Type: AWS::ECS::TaskDefinition
+    Properties:
+      Family: job
+      ContainerDefinitions:
+        - Name: job
+          LogConfiguration:
+            LogDriver: awslogs
+            Options: {awslogs-stream-prefix: job}
+  EventRuleNightly:
+    Type: AWS::Events::Rule
+    DependsOn: [EcsClusterCluster, IamRoleNightlyJobRole]
+    Properties:
+      Name: nightly
+      ScheduleExpression: cron(0 3 * * ? *)
+      Targets:
+        - Id: nightly-job
+          Arn: {Fn::GetAtt: [EcsClusterCluster, Arn]}
+          RoleArn: {Fn::GetAtt: [IamRoleNightlyJobRole, Arn]}
+          EcsParameters:
+            LaunchType: FARGATE
+            TaskCount: 1
+            TaskDefinitionArn: {Ref: EcsTaskDefinitionJob}
+            NetworkConfiguration:
+              AwsVpcConfiguration:
+                AssignPublicIp: DISABLED
+  IamRoleNightlyJobRole:
+    Type: AWS::IAM::Role
+`,
+		},
+		{
+			name: "state machine integration",
+			elements: []any{
+				&construct.Resource{ID: id("aws:sfn_state_machine:flow")},
+				&construct.Resource{ID: id("aws:iam_role:api-flow-role")},
+				&construct.Resource{ID: id("aws:rest_api:api")},
+				&construct.Resource{
+					ID:         id("aws:api_method:api:api-flow"),
+					Properties: construct.Properties{"HttpMethod": "ANY", "RestApi": id("aws:rest_api:api")},
+				},
+				&construct.Resource{
+					ID: id("aws:api_integration:api:api-flow"),
+					Properties: construct.Properties{
+						"Method":                id("aws:api_method:api:api-flow"),
+						"Credentials":           id("aws:iam_role:api-flow-role"),
+						"IntegrationHttpMethod": "POST",
+						"Type":                  "AWS",
+						"Uri":                   construct.PropertyRef{Resource: id("aws:sfn_state_machine:flow"), Property: "ApiStartExecutionUri"},
+						"RequestTemplates": map[string]any{
+							"application/json": construct.PropertyRef{
+								Resource: id("aws:sfn_state_machine:flow"),
+								Property: "ApiStartExecutionTemplate",
+							},
+						},
+					},
+				},
+				"aws:api_integration:api:api-flow -> aws:api_method:api:api-flow",
+				"aws:api_integration:api:api-flow -> aws:iam_role:api-flow-role",
+				"aws:api_integration:api:api-flow -> aws:sfn_state_machine:flow",
+				"aws:api_method:api:api-flow -> aws:rest_api:api",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Resources:
+  ApiMethodApiApiFlow:
+    Type: AWS::ApiGateway::Method
+    DependsOn: [IamRoleApiFlowRole, RestApiApi, SfnStateMachineFlow]
+    Properties:
+      HttpMethod: ANY
+      ResourceId: {Fn::GetAtt: [RestApiApi, RootResourceId]}
+      RestApiId: {Ref: RestApiApi}
+      Integration:
+        Credentials: {Fn::GetAtt: [IamRoleApiFlowRole, Arn]}
+        IntegrationHttpMethod: POST
+        RequestTemplates:
+          application/json:
+            Fn::Sub: '{"input": "$util.escapeJavaScript($input.json(''$''))", "stateMachineArn": "${SfnStateMachineFlow.Arn}"}'
+        Type: AWS
+        Uri: {Fn::Sub: "arn:${AWS::Partition}:apigateway:${AWS::Region}:states:action/StartExecution"}
+  IamRoleApiFlowRole:
+    Type: AWS::IAM::Role
+  RestApiApi:
+    Type: AWS::ApiGateway::RestApi
+    Properties:
+      Name: api
+  SfnStateMachineFlow:
+    Type: AWS::StepFunctions::StateMachine
+    Properties:
+      StateMachineName: flow
+`,
+		},
+		{
+			name: "attribute of imported resource",
+			elements: []any{
+				&construct.Resource{ID: id("aws:iam_role:role"), Imported: true},
+				&construct.Resource{
+					ID:         id("aws:lambda_function:fn"),
+					Properties: construct.Properties{"ExecutionRole": id("aws:iam_role:role")},
+				},
+			},
+			wantErr: "cannot get attribute Arn of aws:iam_role:role since it is a parameter",
+		},
+		{
+			name: "unmapped attribute",
+			elements: []any{
+				&construct.Resource{ID: id("aws:ecr_repo:repo")},
+				&construct.Resource{
+					ID: id("aws:lambda_function:fn"),
+					Properties: construct.Properties{
+						"EnvironmentVariables": map[string]any{
+							"REPO": construct.PropertyRef{Resource: id("aws:ecr_repo:repo"), Property: "RepositoryUrl"},
+						},
+					},
+				},
+			},
+			wantErr: "no CloudFormation attribute for aws:ecr_repo:repo#RepositoryUrl",
+		},
+		{
+			name: "unmapped type",
+			elements: []any{
+				&construct.Resource{ID: id("aws:unknown:thing")},
+				&construct.Resource{
+					ID:         id("aws:lambda_function:fn"),
+					Properties: construct.Properties{"EnvironmentVariables": map[string]any{"THING": id("aws:unknown:thing")}},
+				},
+				"aws:lambda_function:fn -> aws:unknown:thing",
+			},
+			wantErr: "resource types not supported by CloudFormation: aws:unknown",
+		},
+		{
+			name: "imported unmapped type",
+			elements: []any{
+				&construct.Resource{ID: id("aws:unknown:thing"), Imported: true},
+				&construct.Resource{
+					ID: id("aws:lambda_function:fn"),
+					Properties: construct.Properties{
+						"EnvironmentVariables": map[string]any{
+							"THING":     id("aws:unknown:thing"),
+							"THING_URL": construct.PropertyRef{Resource: id("aws:unknown:thing"), Property: "Url"},
+						},
+					},
+				},
+				"aws:lambda_function:fn -> aws:unknown:thing",
+			},
+			want: `
+AWSTemplateFormatVersion: "2010-09-09"
+Parameters:
+  UnknownThing:
+    Type: String
+    Description: Value for aws:unknown:thing
+  UnknownThingUrl:
+    Type: String
+    Description: Value for aws:unknown:thing#Url
+Resources:
+  LambdaFunctionFn:
+    Type: AWS::Lambda::Function
+    Properties:
+      FunctionName: fn
+      PackageType: Image
+      Environment:
+        Variables:
+          THING: {Ref: UnknownThing}
+          THING_URL: {Ref: UnknownThingUrl}
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := graphtest.MakeGraph(t, construct.NewGraph(), tt.elements...)
+
+			got, err := NewTemplate(g, "")
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			content, err := yaml.Marshal(got)
+			require.NoError(t, err)
+			assert.YAMLEq(t, tt.want, string(content))
+		})
+	}
+}
@@ -1,46 +1,397 @@
 package iac3
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/klothoplatform/klotho/pkg/compiler/types"
 	construct "github.com/klothoplatform/klotho/pkg/construct2"
 	"github.com/klothoplatform/klotho/pkg/engine2/solution_context"
 	kio "github.com/klothoplatform/klotho/pkg/io"
+	csharp_runtime "github.com/klothoplatform/klotho/pkg/lang/csharp/runtimes/aws"
+	"github.com/klothoplatform/klotho/pkg/lang/dockerfile"
+	golang_runtime "github.com/klothoplatform/klotho/pkg/lang/golang/aws_runtime"
+	javascript_runtime "github.com/klothoplatform/klotho/pkg/lang/javascript/aws_runtime"
+	python_runtime "github.com/klothoplatform/klotho/pkg/lang/python/aws_runtime"
+	sitter "github.com/smacker/go-tree-sitter"
 )
 
-// RenderDockerfiles is a temporary workaround for rendering trivial Dockerfiles for resources.
-// Ideally this isn't explicit but instead handled by a template in some fashion.
+type (
+	// dockerfileTemplate is a language template that an image's Dockerfile can be generated from, reusing the
+	// language runtime's Dockerfile. Container is nil for languages which only have a Lambda runtime.
+	dockerfileTemplate struct {
+		Lambda      []byte
+		Container   []byte
+		ProjectFile string
+		// Dispatcher is set when the runtime's final stage runs the Klotho runtime dispatcher. Its EXPOSE, ENTRYPOINT
+		// and CMD are dropped, so the command the container runs comes from the image's Entrypoint and Command.
+		Dispatcher bool
+	}
+
+	dockerfileTemplateData struct {
+		ProjectFilePath string
+		CSProjFile      string
+		// AssemblyName is only used by the dispatcher's CMD, which is dropped
+		AssemblyName string
+	}
+
+	// imageDockerfile is the Dockerfile description of an aws:ecr_image.
+	imageDockerfile struct {
+		Path         string
+		BaseImage    string
+		Template     string
+		ProjectFile  string
+		BuildArgs    map[string]string
+		ExposedPorts []int
+		Entrypoint   []string
+		Command      []string
+		// IsLambda is set when the image is used by a Lambda function, which selects the Lambda variant of the
+		// language template.
+		IsLambda bool
+	}
+)
+
+var dockerfileTemplates = map[string]dockerfileTemplate{
+	"nodejs": {
+		Lambda:      javascript_runtime.DockerfileLambda,
+		Container:   javascript_runtime.DockerfileFargate,
+		ProjectFile: "package.json",
+		Dispatcher:  true,
+	},
+	"python": {
+		Lambda:      python_runtime.DockerfileLambda,
+		Container:   python_runtime.DockerfileFargate,
+		ProjectFile: "requirements.txt",
+		Dispatcher:  true,
+	},
+	"go": {
+		Lambda:    golang_runtime.DockerfileLambda,
+		Container: golang_runtime.DockerfileExec,
+	},
+	"dotnet": {
+		Lambda:     csharp_runtime.DockerfileLambda,
+		Dispatcher: true,
+	},
+}
+
+// RenderDockerfiles generates the Dockerfile for every aws:ecr_image which describes one, either from its
+// BaseImage alone or from a language Template. Images with neither are assumed to use a Dockerfile
+// provided by the user and are skipped.
 func RenderDockerfiles(ctx solution_context.SolutionContext) ([]kio.File, error) {
 	resources, err := construct.ReverseTopologicalSort(ctx.DeploymentGraph())
 	if err != nil {
 		return nil, err
 	}
 	var files []kio.File
+	var errs error
 	for _, rid := range resources {
 		if rid.QualifiedTypeName() != "aws:ecr_image" {
 			continue
 		}
 
-		res, err := ctx.DeploymentGraph().Vertex(rid)
+		img, err := imageDockerfileFromGraph(ctx.DeploymentGraph(), rid)
 		if err != nil {
-			return nil, err
+			errs = errors.Join(errs, fmt.Errorf("could not read dockerfile properties for %s: %w", rid, err))
+			continue
+		}
+		if img.BaseImage == "" && img.Template == "" {
+			continue
 		}
 
-		baseImage, err := res.GetProperty("BaseImage")
+		f, err := img.Render()
 		if err != nil {
-			return nil, err
-		}
-		if baseImage == nil {
+			errs = errors.Join(errs, fmt.Errorf("could not render dockerfile for %s: %w", rid, err))
 			continue
 		}
+		files = append(files, f)
+	}
+	return files, errs
+}
+
+func imageDockerfileFromGraph(g construct.Graph, rid construct.ResourceId) (imageDockerfile, error) {
+	var img imageDockerfile
+	res, err := g.Vertex(rid)
+	if err != nil {
+		return img, err
+	}
 
-		dockerfile, err := res.GetProperty("Dockerfile")
+	var errs error
+	stringProp := func(name string) string {
+		v, err := res.GetProperty(name)
 		if err != nil {
-			return nil, err
+			errs = errors.Join(errs, err)
+			return ""
+		}
+		if v == nil {
+			return ""
+		}
+		s, ok := v.(string)
+		if !ok {
+			errs = errors.Join(errs, fmt.Errorf("property %s is not a string (is %T)", name, v))
+		}
+		return s
+	}
+	img.Path = stringProp("Dockerfile")
+	img.BaseImage = stringProp("BaseImage")
+	img.Template = stringProp("Template")
+	img.ProjectFile = stringProp("ProjectFile")
+
+	buildArgs, err := res.GetProperty("BuildArgs")
+	errs = errors.Join(errs, err)
+	if m, ok := buildArgs.(map[string]any); ok {
+		img.BuildArgs = make(map[string]string, len(m))
+		for k, v := range m {
+			img.BuildArgs[k] = fmt.Sprint(v)
+		}
+	} else if m, ok := buildArgs.(map[string]string); ok {
+		img.BuildArgs = m
+	}
+
+	ports, err := res.GetProperty("ExposedPorts")
+	errs = errors.Join(errs, err)
+	if list, ok := ports.([]any); ok {
+		for _, p := range list {
+			port, err := strconv.Atoi(fmt.Sprint(p))
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("invalid exposed port %v: %w", p, err))
+				continue
+			}
+			img.ExposedPorts = append(img.ExposedPorts, port)
+		}
+	}
+
+	stringListProp := func(name string) []string {
+		v, err := res.GetProperty(name)
+		errs = errors.Join(errs, err)
+		list, _ := v.([]any)
+		var strs []string
+		for _, e := range list {
+			strs = append(strs, fmt.Sprint(e))
+		}
+		return strs
+	}
+	img.Entrypoint = stringListProp("Entrypoint")
+	img.Command = stringListProp("Command")
+
+	if img.Path == "" {
+		errs = errors.Join(errs, errors.New("missing Dockerfile path"))
+	}
+
+	upstreams, err := construct.DirectUpstreamDependencies(g, rid)
+	errs = errors.Join(errs, err)
+	for _, up := range upstreams {
+		if up.QualifiedTypeName() == "aws:lambda_function" {
+			img.IsLambda = true
+			break
+		}
+	}
+	return img, errs
+}
+
+// Render generates the Dockerfile and validates it by parsing it as a Dockerfile source file.
+func (img imageDockerfile) Render() (*types.SourceFile, error) {
+	base, err := img.baseContent()
+	if err != nil {
+		return nil, err
+	}
+	f, err := dockerfile.NewFile(img.Path, bytes.NewReader(base))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse base dockerfile: %w", err)
+	}
+	content, err := img.rewrite(f.Tree().RootNode(), f.Program())
+	if err != nil {
+		return nil, err
+	}
+	f, err = dockerfile.NewFile(img.Path, strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse generated dockerfile: %w", err)
+	}
+	if err := validateDockerfile(f.Tree().RootNode()); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// baseContent returns the Dockerfile before any of the image's customizations are applied.
+func (img imageDockerfile) baseContent() ([]byte, error) {
+	if img.Template == "" {
+		return []byte("FROM " + img.BaseImage + "\n"), nil
+	}
+	tmpl, ok := dockerfileTemplates[img.Template]
+	if !ok {
+		return nil, fmt.Errorf("unsupported dockerfile template %q", img.Template)
+	}
+	content := tmpl.Container
+	if img.IsLambda {
+		content = tmpl.Lambda
+	}
+	if content == nil {
+		return nil, fmt.Errorf("dockerfile template %q does not support container images", img.Template)
+	}
+
+	data := dockerfileTemplateData{ProjectFilePath: img.ProjectFile}
+	if data.ProjectFilePath == "" {
+		data.ProjectFilePath = tmpl.ProjectFile
+	}
+	if img.Template == "dotnet" {
+		if img.ProjectFile == "" {
+			return nil, errors.New("dotnet dockerfile template requires a ProjectFile (.csproj)")
+		}
+		data.CSProjFile = img.ProjectFile
+	}
+
+	t, err := template.New(img.Template).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("could not parse dockerfile template %q: %w", img.Template, err)
+	}
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("could not execute dockerfile template %q: %w", img.Template, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// rewrite applies the image's customizations to the parsed base Dockerfile:
+//   - the BaseImage, when set, overrides the image of the final stage (when generated from a template)
+//   - the BuildArgs are declared before the first FROM (so they can be used in FROM instructions) and in every stage
+//   - the ExposedPorts, Entrypoint and Command replace any the final stage already declares
+//   - the runtime dispatcher's EXPOSE, ENTRYPOINT and CMD are dropped from the final stage
+func (img imageDockerfile) rewrite(root *sitter.Node, program []byte) (string, error) {
+	dispatcher := dockerfileTemplates[img.Template].Dispatcher
+
+	finalStage := -1
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		if root.NamedChild(i).Type() == "from_instruction" {
+			finalStage = i
+		}
+	}
+	if finalStage < 0 {
+		return "", errors.New("dockerfile has no FROM instruction")
+	}
+
+	argNames := make([]string, 0, len(img.BuildArgs))
+	for name := range img.BuildArgs {
+		argNames = append(argNames, name)
+	}
+	sort.Strings(argNames)
+
+	var out strings.Builder
+	for _, name := range argNames {
+		out.WriteString("ARG " + name + "\n")
+	}
+	hasCommand := len(img.Entrypoint) > 0 || len(img.Command) > 0
+	var prevEnd uint32
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		node := root.NamedChild(i)
+		out.Write(program[prevEnd:node.StartByte()])
+		prevEnd = node.EndByte()
+
+		if i > finalStage && dispatcher {
+			switch node.Type() {
+			case "expose_instruction", "entrypoint_instruction", "cmd_instruction":
+				skipLine(&out)
+				continue
+			}
+		}
+		if i > finalStage && (node.Type() == "entrypoint_instruction" || node.Type() == "cmd_instruction") {
+			hasCommand = true
 		}
 
-		files = append(files, &kio.RawFile{
-			FPath:   dockerfile.(string),
-			Content: []byte("FROM " + baseImage.(string)),
-		})
+		switch node.Type() {
+		case "from_instruction":
+			if i == finalStage && img.Template != "" && img.BaseImage != "" {
+				out.WriteString("FROM " + img.BaseImage)
+				if alias := node.ChildByFieldName("as"); alias != nil {
+					out.WriteString(" AS " + alias.Content())
+				}
+			} else {
+				out.WriteString(node.Content())
+			}
+			for _, name := range argNames {
+				out.WriteString("\nARG " + name)
+			}
+			continue
+
+		case "expose_instruction":
+			if i > finalStage && len(img.ExposedPorts) > 0 {
+				skipLine(&out)
+				continue
+			}
+
+		case "entrypoint_instruction":
+			if i > finalStage && len(img.Entrypoint) > 0 {
+				skipLine(&out)
+				continue
+			}
+
+		case "cmd_instruction":
+			if i > finalStage && len(img.Command) > 0 {
+				skipLine(&out)
+				continue
+			}
+		}
+		out.WriteString(node.Content())
+	}
+	out.Write(program[prevEnd:])
+	if img.Template != "" && !hasCommand {
+		return "", fmt.Errorf("dockerfile template %q requires an Entrypoint or Command for the image", img.Template)
+	}
+
+	content := strings.TrimRight(out.String(), "\n") + "\n"
+	if len(img.ExposedPorts) > 0 {
+		ports := make([]string, len(img.ExposedPorts))
+		for i, p := range img.ExposedPorts {
+			ports[i] = strconv.Itoa(p)
+		}
+		content += "EXPOSE " + strings.Join(ports, " ") + "\n"
+	}
+	if len(img.Entrypoint) > 0 {
+		content += "ENTRYPOINT " + execForm(img.Entrypoint) + "\n"
+	}
+	if len(img.Command) > 0 {
+		content += "CMD " + execForm(img.Command) + "\n"
+	}
+	return content, nil
+}
+
+// execForm formats the arguments as the JSON array form of an ENTRYPOINT or CMD instruction.
+func execForm(args []string) string {
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = strconv.Quote(a)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// skipLine removes the whitespace already written for a dropped instruction so that it doesn't leave an empty line.
+func skipLine(out *strings.Builder) {
+	s := out.String()
+	trimmed := strings.TrimRight(s, " \t")
+	trimmed = strings.TrimSuffix(trimmed, "\n")
+	if len(trimmed) != len(s) {
+		out.Reset()
+		out.WriteString(trimmed)
+	}
+}
+
+func validateDockerfile(root *sitter.Node) error {
+	if root.HasError() {
+		return fmt.Errorf("generated dockerfile is invalid: %s", root.String())
+	}
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		switch root.NamedChild(i).Type() {
+		case "comment", "arg_instruction":
+			// Only comments and ARGs may precede the first FROM
+			continue
+		case "from_instruction":
+			return nil
+		}
+		return fmt.Errorf("generated dockerfile must start with a FROM instruction, found %s", root.NamedChild(i).Type())
 	}
-	return files, nil
+	return errors.New("generated dockerfile has no FROM instruction")
 }
@@ -0,0 +1,240 @@
+package iac3
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_imageDockerfile_Render(t *testing.T) {
+	tests := []struct {
+		name    string
+		img     imageDockerfile
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "base image only",
+			img:  imageDockerfile{Path: "img.Dockerfile", BaseImage: "nginx:latest"},
+			want: "FROM nginx:latest\n",
+		},
+		{
+			name: "base image with customizations",
+			img: imageDockerfile{
+				Path:         "img.Dockerfile",
+				BaseImage:    "nginx:latest",
+				BuildArgs:    map[string]string{"VERSION": "1", "ENV": "prod"},
+				ExposedPorts: []int{80, 443},
+				Entrypoint:   []string{"nginx", "-g", "daemon off;"},
+			},
+			want: `ARG ENV
+ARG VERSION
+FROM nginx:latest
+ARG ENV
+ARG VERSION
+EXPOSE 80 443
+ENTRYPOINT ["nginx", "-g", "daemon off;"]
+`,
+		},
+		{
+			name: "build args usable in FROM",
+			img: imageDockerfile{
+				Path:      "img.Dockerfile",
+				BaseImage: "node:${NODE_VERSION}-alpine",
+				BuildArgs: map[string]string{"NODE_VERSION": "18"},
+			},
+			want: `ARG NODE_VERSION
+FROM node:${NODE_VERSION}-alpine
+ARG NODE_VERSION
+`,
+		},
+		{
+			name: "go container template with base image",
+			img: imageDockerfile{
+				Path:      "img.Dockerfile",
+				Template:  "go",
+				BaseImage: "gcr.io/distroless/static",
+				BuildArgs: map[string]string{"VERSION": "1"},
+			},
+			want: `ARG VERSION
+FROM golang:alpine as builder
+ARG VERSION
+RUN apk update && apk add ca-certificates && rm -rf /var/cache/apk/*
+
+WORKDIR /usr/src/app
+ENV GOOS=linux GOARCH=amd64 CGO_ENABLED=0
+COPY . .
+RUN go mod tidy && go mod download && go mod verify
+RUN go build -o /usr/local/bin/app
+
+FROM gcr.io/distroless/static
+ARG VERSION
+COPY --from=builder /etc/ssl/certs/ca-certificates.crt /etc/ssl/certs/ca-certificates.crt
+COPY --from=builder /usr/local/bin/app main
+ENTRYPOINT ["./main"]
+`,
+		},
+		{
+			name: "go template replaces entrypoint",
+			img: imageDockerfile{
+				Path:       "img.Dockerfile",
+				Template:   "go",
+				Entrypoint: []string{"./main", "serve"},
+			},
+			want: `FROM golang:alpine as builder
+RUN apk update && apk add ca-certificates && rm -rf /var/cache/apk/*
+
+WORKDIR /usr/src/app
+ENV GOOS=linux GOARCH=amd64 CGO_ENABLED=0
+COPY . .
+RUN go mod tidy && go mod download && go mod verify
+RUN go build -o /usr/local/bin/app
+
+FROM scratch
+COPY --from=builder /etc/ssl/certs/ca-certificates.crt /etc/ssl/certs/ca-certificates.crt
+COPY --from=builder /usr/local/bin/app main
+ENTRYPOINT ["./main", "serve"]
+`,
+		},
+		{
+			name: "nodejs container template",
+			img: imageDockerfile{
+				Path:         "img.Dockerfile",
+				Template:     "nodejs",
+				ExposedPorts: []int{8080},
+				Entrypoint:   []string{"npm"},
+				Command:      []string{"start"},
+			},
+			want: `FROM node:alpine
+WORKDIR /usr/src/app
+COPY package.json ./
+RUN npm install
+COPY . ./
+EXPOSE 8080
+ENTRYPOINT ["npm"]
+CMD ["start"]
+`,
+		},
+		{
+			name: "python container template drops the runtime dispatcher",
+			img: imageDockerfile{
+				Path:     "img.Dockerfile",
+				Template: "python",
+				Command:  []string{"python", "app.py"},
+			},
+			want: `FROM python:3-bullseye
+
+# Install some common dependencies for python packages
+RUN apt-get update \
+    && apt-get install -y --no-install-recommends \
+    autoconf automake binutils gcc g++ \
+    clang make zlib1g zlib1g-dev \
+    && apt-get clean \
+    && rm -rf /var/lib/apt/lists/* /tmp/* /var/tmp/*
+
+WORKDIR /usr/src/app
+
+COPY requirements.txt ./
+
+RUN pip install -r requirements.txt
+
+COPY . ./
+
+ENV PYTHONPATH=.:${PYTHONPATH}
+CMD ["python", "app.py"]
+`,
+		},
+		{
+			name:    "nodejs container template requires a command",
+			img:     imageDockerfile{Path: "img.Dockerfile", Template: "nodejs"},
+			wantErr: true,
+		},
+		{
+			name: "nodejs lambda template",
+			img: imageDockerfile{
+				Path:        "img.Dockerfile",
+				Template:    "nodejs",
+				ProjectFile: "app/package.json",
+				Command:     []string{"index.handler"},
+				IsLambda:    true,
+			},
+			want: `FROM public.ecr.aws/lambda/nodejs:16
+
+COPY app/package.json ./
+RUN npm install
+COPY . ./
+CMD ["index.handler"]
+`,
+		},
+		{
+			name: "dotnet lambda template publishes to the directory it copies from",
+			img: imageDockerfile{
+				Path:        "img.Dockerfile",
+				Template:    "dotnet",
+				ProjectFile: "src/MyApp.csproj",
+				Command:     []string{"MyApp::MyApp.Function::FunctionHandler"},
+				IsLambda:    true,
+			},
+			want: `# dotnet builder image
+# https://hub.docker.com/_/microsoft-dotnet
+FROM mcr.microsoft.com/dotnet/sdk:6.0 AS build
+
+WORKDIR /app
+
+# Copy klotho-compiled project files from host
+COPY . .
+
+# Publish the dotnet assembly for an execution unit
+RUN dotnet publish src/MyApp.csproj -o /app/klotho_bin
+
+# AWS Lambda dotnet 6 base image
+FROM public.ecr.aws/lambda/dotnet:6
+
+WORKDIR ${LAMBDA_TASK_ROOT}
+
+# Copy "dotnet publish" artifacts from build image
+COPY --from=build /app/klotho_bin ./
+
+# Pass the assembly-qualified name of the function handler to the AWS Lambda Runtime
+CMD ["MyApp::MyApp.Function::FunctionHandler"]
+`,
+		},
+		{
+			name:    "dotnet requires project file",
+			img:     imageDockerfile{Path: "img.Dockerfile", Template: "dotnet", IsLambda: true},
+			wantErr: true,
+		},
+		{
+			name:    "dotnet has no container template",
+			img:     imageDockerfile{Path: "img.Dockerfile", Template: "dotnet", ProjectFile: "a.csproj"},
+			wantErr: true,
+		},
+		{
+			name:    "unknown template",
+			img:     imageDockerfile{Path: "img.Dockerfile", Template: "cobol"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			f, err := tt.img.Render()
+			if tt.wantErr {
+				assert.Error(err)
+				return
+			}
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.img.Path, f.Path())
+			buf := new(bytes.Buffer)
+			_, err = f.WriteTo(buf)
+			if !assert.NoError(err) {
+				return
+			}
+			assert.Equal(tt.want, buf.String())
+		})
+	}
+}
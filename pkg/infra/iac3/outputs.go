@@ -0,0 +1,118 @@
+package iac3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	kio "github.com/klothoplatform/klotho/pkg/io"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base2"
+)
+
+type (
+	// StackOutput describes a single export of the generated stack.
+	StackOutput struct {
+		// Name is the name of the stack output, as exported from index.ts
+		Name string `json:"name"`
+		// Resource is the resource the output is exported from
+		Resource construct.ResourceId `json:"resource"`
+		// Export is the name of the export in the resource's template
+		Export string `json:"export"`
+		// Property is the path of the property the export's value comes from, or the export's name
+		// if it does not come from a known property
+		Property string `json:"property"`
+		// Type is the knowledge base type of the property, or "any" if the export is not a known property
+		Type string `json:"type"`
+	}
+
+	// OutputSelector selects which exports are rendered. The Resource is used as a selector
+	// (see [construct.ResourceId.Matches]) and the Property matches either the export's name or the path of
+	// the property it exports. An empty Property matches all exports of the resource.
+	OutputSelector construct.PropertyRef
+)
+
+const outputsManifestPath = "outputs.json"
+
+// ParseOutputSelector parses a selector of the form `provider:type[:namespace][:name][#Export]`,
+// for example `aws:load_balancer#DnsName`.
+func ParseOutputSelector(s string) (OutputSelector, error) {
+	var sel OutputSelector
+	resource, property, _ := strings.Cut(s, "#")
+	err := sel.Resource.UnmarshalText([]byte(resource))
+	if err != nil {
+		return sel, fmt.Errorf("invalid output selector %q: %w", s, err)
+	}
+	sel.Property = property
+	return sel, nil
+}
+
+func (sel OutputSelector) Matches(rid construct.ResourceId, export, property string) bool {
+	return sel.Resource.Matches(rid) && (sel.Property == "" || sel.Property == export || sel.Property == property)
+}
+
+func (sel OutputSelector) String() string {
+	return construct.PropertyRef(sel).String()
+}
+
+// includeExport returns whether the export should be rendered based on the configured selectors.
+// When there are no selectors, all exports are included.
+func (tc *TemplatesCompiler) includeExport(rid construct.ResourceId, export, property string) bool {
+	if len(tc.outputSelectors) == 0 {
+		return true
+	}
+	if tc.matchedSelectors == nil {
+		tc.matchedSelectors = make([]bool, len(tc.outputSelectors))
+	}
+	included := false
+	for i, sel := range tc.outputSelectors {
+		if sel.Matches(rid, export, property) {
+			tc.matchedSelectors[i] = true
+			included = true
+		}
+	}
+	return included
+}
+
+// OutputsManifest renders the outputs.json file describing all the stack outputs rendered so far.
+// It returns an error for any selector which did not match an export.
+func (tc *TemplatesCompiler) OutputsManifest(kb knowledgebase.TemplateKB) (*kio.RawFile, error) {
+	var errs error
+	for i, sel := range tc.outputSelectors {
+		if i >= len(tc.matchedSelectors) || !tc.matchedSelectors[i] {
+			errs = errors.Join(errs, fmt.Errorf("output selector %q did not match any resource export", sel))
+		}
+	}
+
+	outputs := make([]StackOutput, len(tc.outputs))
+	copy(outputs, tc.outputs)
+
+	for i, out := range outputs {
+		outputs[i].Type = "any"
+		rt, err := kb.GetResourceTemplate(out.Resource)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("could not get template for output %s: %w", out.Name, err))
+			continue
+		}
+		if prop := rt.GetProperty(out.Property); prop != nil {
+			outputs[i].Type = prop.Type()
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	sort.Slice(outputs, func(i, j int) bool {
+		return outputs[i].Name < outputs[j].Name
+	})
+
+	content, err := json.MarshalIndent(outputs, "", "    ")
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal outputs manifest: %w", err)
+	}
+	return &kio.RawFile{
+		FPath:   outputsManifestPath,
+		Content: append(content, '\n'),
+	}, nil
+}
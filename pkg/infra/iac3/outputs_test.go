@@ -0,0 +1,118 @@
+package iac3
+
+import (
+	"testing"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base2"
+	"github.com/klothoplatform/klotho/pkg/knowledge_base2/properties"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutputSelector_Matches(t *testing.T) {
+	lb := construct.ResourceId{Provider: "aws", Type: "load_balancer", Name: "lb"}
+	tests := []struct {
+		name     string
+		selector string
+		rid      construct.ResourceId
+		export   string
+		property string
+		want     bool
+	}{
+		{
+			name:     "type and export",
+			selector: "aws:load_balancer#DomainName",
+			rid:      lb,
+			export:   "DomainName",
+			want:     true,
+		},
+		{
+			name:     "exported property",
+			selector: "aws:load_balancer#DnsName",
+			rid:      lb,
+			export:   "DomainName",
+			property: "DnsName",
+			want:     true,
+		},
+		{
+			name:     "different export",
+			selector: "aws:load_balancer#DomainName",
+			rid:      lb,
+			export:   "Arn",
+			want:     false,
+		},
+		{
+			name:     "all exports",
+			selector: "aws:load_balancer",
+			rid:      lb,
+			export:   "Arn",
+			want:     true,
+		},
+		{
+			name:     "by name",
+			selector: "aws:load_balancer:other#DomainName",
+			rid:      lb,
+			export:   "DomainName",
+			want:     false,
+		},
+		{
+			name:     "different type",
+			selector: "aws:s3_bucket#DomainName",
+			rid:      lb,
+			export:   "DomainName",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sel, err := ParseOutputSelector(tt.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sel.Matches(tt.rid, tt.export, tt.property))
+		})
+	}
+}
+
+func TestTemplatesCompiler_OutputsManifest(t *testing.T) {
+	kb := knowledgebase.NewKB()
+	err := kb.AddResourceTemplate(&knowledgebase.ResourceTemplate{
+		QualifiedTypeName: "aws:load_balancer",
+		Properties: knowledgebase.Properties{
+			"DnsName": &properties.StringProperty{
+				PropertyDetails: knowledgebase.PropertyDetails{Name: "DnsName", Path: "DnsName"},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	lb := construct.ResourceId{Provider: "aws", Type: "load_balancer", Name: "lb"}
+	tc := &TemplatesCompiler{
+		outputs: []StackOutput{
+			{Name: "lb_Url", Resource: lb, Export: "Url", Property: "Url"},
+			{Name: "lb_DomainName", Resource: lb, Export: "DomainName", Property: "DnsName"},
+		},
+	}
+	f, err := tc.OutputsManifest(kb)
+	require.NoError(t, err)
+	assert.Equal(t, "outputs.json", f.Path())
+	assert.JSONEq(t, `[
+		{"name": "lb_DomainName", "resource": "aws:load_balancer:lb", "export": "DomainName", "property": "DnsName", "type": "string"},
+		{"name": "lb_Url", "resource": "aws:load_balancer:lb", "export": "Url", "property": "Url", "type": "any"}
+	]`, string(f.Content))
+}
+
+func TestTemplatesCompiler_OutputsManifest_unmatchedSelector(t *testing.T) {
+	matched, err := ParseOutputSelector("aws:load_balancer#DnsName")
+	require.NoError(t, err)
+	unmatched, err := ParseOutputSelector("aws:s3_bucket#BucketName")
+	require.NoError(t, err)
+
+	lb := construct.ResourceId{Provider: "aws", Type: "load_balancer", Name: "lb"}
+	tc := &TemplatesCompiler{outputSelectors: []OutputSelector{matched, unmatched}}
+	assert.True(t, tc.includeExport(lb, "DomainName", "DnsName"))
+
+	_, err = tc.OutputsManifest(knowledgebase.NewKB())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"aws:s3_bucket#BucketName"`)
+	assert.NotContains(t, err.Error(), `"aws:load_balancer#DnsName"`)
+}
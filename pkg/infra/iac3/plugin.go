@@ -23,6 +23,10 @@ import (
 type (
 	PulumiConfig struct {
 		AppName string
+		// Outputs selects which resource exports become stack outputs. If empty, all exports are included.
+		Outputs []OutputSelector
+		// Verify runs [TemplatesCompiler.Verify] against the generated program.
+		Verify bool
 	}
 
 	Plugin struct {
@@ -66,9 +70,14 @@ func (p Plugin) Translate(ctx solution_context.SolutionContext) ([]kio.File, err
 	if err != nil {
 		return nil, fmt.Errorf("error adding pulumi kubernetes providers: %w", err)
 	}
+	err = addPulumiAwsProviders(ctx.DeploymentGraph(), p.KB)
+	if err != nil {
+		return nil, fmt.Errorf("error adding pulumi aws providers: %w", err)
+	}
 	tc := &TemplatesCompiler{
-		graph:     ctx.DeploymentGraph(),
-		templates: &templateStore{fs: templatesFS},
+		graph:           ctx.DeploymentGraph(),
+		templates:       &templateStore{fs: templatesFS},
+		outputSelectors: p.Config.Outputs,
 	}
 	tc.vars, err = VariablesFromGraph(tc.graph)
 	if err != nil {
@@ -98,6 +107,12 @@ func (p Plugin) Translate(ctx solution_context.SolutionContext) ([]kio.File, err
 		return nil, errs
 	}
 
+	if p.Config.Verify {
+		if err := tc.Verify(p.KB, buf.Bytes()); err != nil {
+			return nil, fmt.Errorf("generated program failed verification: %w", err)
+		}
+	}
+
 	indexTs := &kio.RawFile{
 		FPath:   `index.ts`,
 		Content: buf.Bytes(),
@@ -130,7 +145,12 @@ func (p Plugin) Translate(ctx solution_context.SolutionContext) ([]kio.File, err
 		Content: content,
 	}
 
-	files := []kio.File{indexTs, packageJson, pulumiYaml, pulumiStack, tsConfig}
+	outputs, err := tc.OutputsManifest(p.KB)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []kio.File{indexTs, packageJson, pulumiYaml, pulumiStack, tsConfig, outputs}
 
 	dockerfiles, err := RenderDockerfiles(ctx)
 	if err != nil {
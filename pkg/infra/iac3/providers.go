@@ -0,0 +1,182 @@
+package iac3
+
+import (
+	"errors"
+	"fmt"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base2"
+)
+
+var (
+	awsRegionId   = construct.ResourceId{Provider: "aws", Type: "region"}
+	awsProviderId = construct.ResourceId{Provider: "aws", Type: "provider"}
+)
+
+// addPulumiAwsProviders adds an `aws:provider` resource for each distinct region and account configured on an
+// `aws:region` (via its RegionName or AssumeRole properties) and sets it as the Provider of the resources placed in it.
+// Resources without a placement use the stack's default provider. A resource's placement is, in order of precedence:
+//  1. the region in its Region property
+//  2. the placement of the resource its ID is namespaced by (eg. a subnet is placed with its VPC)
+//  3. the region its ID is namespaced by, for resources without a namespace property (eg. aws:s3_bucket:west:replica)
+//  4. the placement of its dependents, when they all agree (eg. a function's log group is placed with the function)
+func addPulumiAwsProviders(g construct.Graph, kb *knowledgebase.KnowledgeBase) error {
+	providers, err := regionProviders(g)
+	if err != nil || len(providers) == 0 {
+		return err
+	}
+
+	p := placer{g: g, kb: kb, providers: providers, explicit: make(map[construct.ResourceId]construct.ResourceId)}
+
+	// Dependents come first in the topological sort, so their placements are known when inheriting them.
+	ids, err := construct.TopologicalSort(g)
+	if err != nil {
+		return err
+	}
+	placements := make(map[construct.ResourceId]construct.ResourceId)
+	var errs error
+	for _, id := range ids {
+		if id.Provider != "aws" || awsRegionId.Matches(id) || awsProviderId.Matches(id) {
+			continue
+		}
+		provider, err := p.explicitPlacement(id)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if provider.IsZero() {
+			provider, err = p.inheritedPlacement(id, placements)
+			if err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+		}
+		if provider.IsZero() {
+			continue
+		}
+		placements[id] = provider
+
+		res, err := g.Vertex(id)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		errs = errors.Join(errs, res.SetProperty("Provider", provider), g.AddEdge(id, provider))
+	}
+	return errs
+}
+
+// regionProviders creates the provider resources for the configured regions, returning a map from
+// each region to its provider. Regions which configure the same region and role share a provider.
+func regionProviders(g construct.Graph) (map[construct.ResourceId]construct.ResourceId, error) {
+	providers := make(map[construct.ResourceId]construct.ResourceId)
+	byConfig := make(map[string]construct.ResourceId)
+	err := construct.WalkGraph(g, func(id construct.ResourceId, resource *construct.Resource, nerr error) error {
+		if !awsRegionId.Matches(id) {
+			return nerr
+		}
+		regionName, _ := resource.Properties["RegionName"].(string)
+		assumeRole, _ := resource.Properties["AssumeRole"].(map[string]any)
+		if regionName == "" && len(assumeRole) == 0 {
+			return nerr
+		}
+		key := fmt.Sprintf("%s|%v", regionName, assumeRole["RoleArn"])
+		if provider, ok := byConfig[key]; ok {
+			providers[id] = provider
+			return nerr
+		}
+		provider := &construct.Resource{
+			ID: construct.ResourceId{
+				Provider: awsProviderId.Provider,
+				Type:     awsProviderId.Type,
+				Name:     id.Name,
+			},
+			Properties: construct.Properties{
+				"Region": id,
+			},
+		}
+		if len(assumeRole) > 0 {
+			provider.Properties["AssumeRole"] = assumeRole
+		}
+		if err := g.AddVertex(provider); err != nil {
+			return errors.Join(nerr, err)
+		}
+		if err := g.AddEdge(provider.ID, id); err != nil {
+			return errors.Join(nerr, err)
+		}
+		byConfig[key] = provider.ID
+		providers[id] = provider.ID
+		return nerr
+	})
+	return providers, err
+}
+
+type placer struct {
+	g         construct.Graph
+	kb        *knowledgebase.KnowledgeBase
+	providers map[construct.ResourceId]construct.ResourceId
+	explicit  map[construct.ResourceId]construct.ResourceId
+}
+
+// explicitPlacement returns the provider for the resource from its Region property or namespace.
+func (p placer) explicitPlacement(id construct.ResourceId) (construct.ResourceId, error) {
+	if provider, ok := p.explicit[id]; ok {
+		return provider, nil
+	}
+	res, err := p.g.Vertex(id)
+	if err != nil {
+		return construct.ResourceId{}, err
+	}
+
+	var provider construct.ResourceId
+	if region, ok := res.Properties["Region"].(construct.ResourceId); ok && awsRegionId.Matches(region) {
+		provider = p.providers[region]
+	} else if id.Namespace != "" {
+		var namespaceProp knowledgebase.Property
+		if p.kb != nil {
+			if tmpl, err := p.kb.GetResourceTemplate(id); err == nil && tmpl != nil {
+				namespaceProp = tmpl.GetNamespacedProperty()
+			}
+		}
+		if namespaceProp != nil {
+			parent, err := res.GetProperty(namespaceProp.Details().Path)
+			if err != nil {
+				return construct.ResourceId{}, err
+			}
+			if parentId, ok := parent.(construct.ResourceId); ok && parentId.Name == id.Namespace {
+				provider, err = p.explicitPlacement(parentId)
+				if err != nil {
+					return construct.ResourceId{}, err
+				}
+			}
+		} else {
+			region := construct.ResourceId{Provider: awsRegionId.Provider, Type: awsRegionId.Type, Name: id.Namespace}
+			provider = p.providers[region]
+		}
+	}
+	p.explicit[id] = provider
+	return provider, nil
+}
+
+// inheritedPlacement returns the provider shared by all of the resource's dependents, if any.
+func (p placer) inheritedPlacement(
+	id construct.ResourceId,
+	placements map[construct.ResourceId]construct.ResourceId,
+) (construct.ResourceId, error) {
+	dependents, err := construct.DirectUpstreamDependencies(p.g, id)
+	if err != nil {
+		return construct.ResourceId{}, err
+	}
+	var provider construct.ResourceId
+	for _, dep := range dependents {
+		if awsProviderId.Matches(dep) {
+			continue
+		}
+		depProvider, ok := placements[dep]
+		if !ok || (!provider.IsZero() && depProvider != provider) {
+			return construct.ResourceId{}, nil
+		}
+		provider = depProvider
+	}
+	return provider, nil
+}
@@ -0,0 +1,103 @@
+package iac3
+
+import (
+	"testing"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	"github.com/klothoplatform/klotho/pkg/construct2/graphtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_addPulumiAwsProviders(t *testing.T) {
+	id := func(s string) (rid construct.ResourceId) {
+		err := rid.UnmarshalText([]byte(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	region := func(name string, props construct.Properties) *construct.Resource {
+		return &construct.Resource{ID: id("aws:region:" + name), Properties: props}
+	}
+	tests := []struct {
+		name string
+		// elements of the graph, passed to [graphtest.MakeGraph]
+		elements []any
+		// want maps resources to their expected provider, resources not listed must not have one
+		want map[string]string
+	}{
+		{
+			name: "default region only",
+			elements: []any{
+				region("region-0", nil),
+				"aws:lambda_function:fn -> aws:log_group:fn-log-group",
+			},
+			want: map[string]string{},
+		},
+		{
+			name: "region property and inherited placement",
+			elements: []any{
+				region("west", construct.Properties{"RegionName": "us-west-2"}),
+				&construct.Resource{
+					ID:         id("aws:acm_certificate:cert"),
+					Properties: construct.Properties{"Region": id("aws:region:west")},
+				},
+				"aws:acm_certificate:cert -> aws:region:west",
+				"aws:acm_certificate:cert -> aws:kms_key:key",
+				"aws:cloudfront_distribution:cdn -> aws:acm_certificate:cert",
+			},
+			want: map[string]string{
+				"aws:acm_certificate:cert": "aws:provider:west",
+				"aws:kms_key:key":          "aws:provider:west",
+			},
+		},
+		{
+			name: "region namespace",
+			elements: []any{
+				region("west", construct.Properties{"RegionName": "us-west-2"}),
+				"aws:lambda_function:west:fn -> aws:log_group:fn-log-group",
+				"aws:lambda_function:west:fn -> aws:iam_role:shared-role",
+				"aws:lambda_function:other -> aws:iam_role:shared-role",
+			},
+			want: map[string]string{
+				"aws:lambda_function:west:fn": "aws:provider:west",
+				"aws:log_group:fn-log-group":  "aws:provider:west",
+			},
+		},
+		{
+			name: "same region and role share a provider",
+			elements: []any{
+				region("a", construct.Properties{"RegionName": "eu-west-1", "AssumeRole": map[string]any{"RoleArn": "arn"}}),
+				region("b", construct.Properties{"RegionName": "eu-west-1", "AssumeRole": map[string]any{"RoleArn": "arn"}}),
+				"aws:sqs_queue:a:q1",
+				"aws:sqs_queue:b:q2",
+			},
+			want: map[string]string{
+				"aws:sqs_queue:a:q1": "aws:provider:a",
+				"aws:sqs_queue:b:q2": "aws:provider:a",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert, require := assert.New(t), require.New(t)
+			g := graphtest.MakeGraph(t, construct.NewGraph(), tt.elements...)
+
+			err := addPulumiAwsProviders(g, nil)
+			require.NoError(err)
+
+			got := make(map[string]string)
+			err = construct.WalkGraph(g, func(id construct.ResourceId, resource *construct.Resource, nerr error) error {
+				if provider, ok := resource.Properties["Provider"].(construct.ResourceId); ok {
+					got[id.String()] = provider.String()
+					_, err := g.Edge(id, provider)
+					assert.NoError(err, "missing edge from %s to its provider", id)
+				}
+				return nerr
+			})
+			require.NoError(err)
+			assert.Equal(tt.want, got)
+		})
+	}
+}
@@ -58,9 +58,19 @@ func (tc *TemplatesCompiler) RenderResource(out io.Writer, rid construct.Resourc
 		Object:   tc.vars[rid],
 		Input:    inputs,
 	}
+	exports := make([]string, 0, len(resTmpl.Exports))
+	for export := range resTmpl.Exports {
+		if tc.includeExport(rid, export, resTmpl.ExportProperties[export]) {
+			exports = append(exports, export)
+		}
+	}
+	sort.Strings(exports)
+
 	var errs error
-	for export, tmpl := range resTmpl.Exports {
-		_, err = fmt.Fprintf(out, "\nexport const %s_%s = ", tc.vars[rid], export)
+	for _, export := range exports {
+		tmpl := resTmpl.Exports[export]
+		name := fmt.Sprintf("%s_%s", tc.vars[rid], export)
+		_, err = fmt.Fprintf(out, "\nexport const %s = ", name)
 		if err != nil {
 			errs = errors.Join(errs, fmt.Errorf("could not render export name %s: %w", export, err))
 			continue
@@ -70,6 +80,11 @@ func (tc *TemplatesCompiler) RenderResource(out io.Writer, rid construct.Resourc
 			errs = errors.Join(errs, fmt.Errorf("could not render export value %s: %w", export, err))
 			continue
 		}
+		property := resTmpl.ExportProperties[export]
+		if property == "" {
+			property = export
+		}
+		tc.outputs = append(tc.outputs, StackOutput{Name: name, Resource: rid, Export: export, Property: property})
 	}
 	if errs != nil {
 		return errs
@@ -224,6 +239,10 @@ func (tc *TemplatesCompiler) getInputArgs(r *construct.Resource, template *Resou
 		case "aws:region", "aws:availability_zone", "aws:account_id":
 			continue
 
+		case "aws:provider":
+			// passed explicitly as the `provider` option
+			continue
+
 		case "kubernetes:manifest", "kubernetes:kustomize_directory":
 			ao := tc.NewAppliedOutput(construct.PropertyRef{
 				Resource: dep,
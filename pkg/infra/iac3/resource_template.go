@@ -28,7 +28,10 @@ type (
 		Args              map[string]Arg
 		Path              string
 		Exports           map[string]*template.Template
-		ImportResource    *template.Template
+		// ExportProperties maps an export to the property it exports, for exports whose value is also
+		// returned by the template's properties function
+		ExportProperties map[string]string
+		ImportResource   *template.Template
 	}
 
 	PropertyTemplateData struct {
@@ -110,6 +113,7 @@ func (tc *TemplatesCompiler) ParseTemplate(name string, r io.Reader) (*ResourceT
 	if err != nil {
 		return nil, err
 	}
+	rt.ExportProperties = exportProperties(node)
 	var outputType string
 	rt.ImportResource, outputType, err = importFuncNodeToTemplate(node, name)
 	if err != nil {
@@ -162,7 +166,7 @@ func createNodeToTemplate(node *sitter.Node, name string) (*template.Template, s
 		if !found {
 			return nil, "", fmt.Errorf("no 'return' found in %s body:```\n%s\n```", name, create["body"].Content())
 		}
-		expressionBody = body["return_body"].Content()
+		expressionBody = withProviderOption(body["return_body"])
 	}
 	expressionBody = parameterizeArgs(expressionBody, "")
 	expressionBody = templateComments.ReplaceAllString(expressionBody, "")
@@ -254,6 +258,42 @@ func exportsNodeToTemplate(tc *TemplatesCompiler, tmpl *ResourceTemplate, node *
 	return exportsTemplates, errs
 }
 
+// exportProperties matches each export to the property with the same value, either directly (`object.dnsName`)
+// or by referencing the property (`props.DnsName`).
+func exportProperties(node *sitter.Node) map[string]string {
+	exportsNode, found := doQuery(node, findExportFunc)()
+	if !found {
+		return nil
+	}
+	propsByValue := make(map[string]string)
+	if propsNode, found := doQuery(node, findPropsFuncQuery)(); found {
+		nextProp := doQuery(propsNode["body"], findPropertyQuery)
+		for {
+			propMatches, found := nextProp()
+			if !found {
+				break
+			}
+			propsByValue[propMatches["value"].Content()] = propMatches["key"].Content()
+		}
+	}
+
+	exportProps := make(map[string]string)
+	nextExport := doQuery(exportsNode["body"], findPropertyQuery)
+	for {
+		exportMatches, found := nextExport()
+		if !found {
+			break
+		}
+		value := exportMatches["value"].Content()
+		if m := parameterizePropsRegex.FindStringSubmatch(value); m != nil && m[0] == value {
+			exportProps[exportMatches["key"].Content()] = m[1]
+		} else if prop, ok := propsByValue[value]; ok {
+			exportProps[exportMatches["key"].Content()] = prop
+		}
+	}
+	return exportProps
+}
+
 func importFuncNodeToTemplate(node *sitter.Node, name string) (*template.Template, string, error) {
 	importFunc := doQuery(node, findImportFunc)
 	imp, found := importFunc()
@@ -266,7 +306,7 @@ func importFuncNodeToTemplate(node *sitter.Node, name string) (*template.Templat
 	if !found {
 		return nil, "", fmt.Errorf("no 'return' found in %s body:```\n%s\n```", name, imp["body"].Content())
 	}
-	expressionBody = body["return_body"].Content()
+	expressionBody = withProviderOption(body["return_body"])
 
 	expressionBody = parameterizeArgs(expressionBody, "")
 	expressionBody = templateComments.ReplaceAllString(expressionBody, "")
@@ -278,6 +318,64 @@ func importFuncNodeToTemplate(node *sitter.Node, name string) (*template.Templat
 	return tmpl, outputType, err
 }
 
+// withProviderOption returns the content of the expression, with the provider resource option added if the expression
+// is an `aws` resource constructor (`new aws.x.Y(name, args[, opts])`) or lookup (`aws.x.Y.get(name, id[, state[, opts]])`).
+// Other expressions, such as invokes or immediately invoked functions, are returned as-is and need to declare and use a
+// Provider arg themselves.
+func withProviderOption(expr *sitter.Node) string {
+	content := expr.Content()
+
+	var callee *sitter.Node
+	var optsIndex int
+	switch expr.Type() {
+	case "new_expression":
+		callee = expr.ChildByFieldName("constructor")
+		optsIndex = 2
+	case "call_expression":
+		callee = expr.ChildByFieldName("function")
+		if callee == nil || callee.Type() != "member_expression" || callee.ChildByFieldName("property").Content() != "get" {
+			return content
+		}
+		optsIndex = 3
+	default:
+		return content
+	}
+	if callee == nil || !strings.HasPrefix(callee.Content(), "aws.") {
+		return content
+	}
+	argsNode := expr.ChildByFieldName("arguments")
+	if argsNode == nil {
+		return content
+	}
+	var args []*sitter.Node
+	for i := 0; i < int(argsNode.NamedChildCount()); i++ {
+		arg := argsNode.NamedChild(i)
+		if arg.Type() != "comment" {
+			args = append(args, arg)
+		}
+	}
+	if len(args) == 0 {
+		return content
+	}
+
+	start := expr.StartByte()
+	insert := func(at uint32, text string) string {
+		return content[:at-start] + text + content[at-start:]
+	}
+	switch {
+	case len(args) == optsIndex+1 && args[optsIndex].Type() == "object":
+		// Add to the existing options object, right after its opening brace. The newline keeps the brace from
+		// being parsed as part of the action and is trimmed by it.
+		return insert(args[optsIndex].StartByte()+1, "\n{{- if .Provider }} provider: args.Provider,{{ end }}")
+
+	case len(args) <= optsIndex:
+		// Pad any missing optional arguments before the options
+		padding := strings.Repeat(", undefined", optsIndex-len(args))
+		return insert(args[len(args)-1].EndByte(), "{{ if .Provider }}"+padding+", { provider: args.Provider }{{ end }}")
+	}
+	return content
+}
+
 var templateTSLang = types.SourceLanguage{
 	ID:     types.LanguageId("ts"),
 	Sitter: typescript.GetLanguage(),
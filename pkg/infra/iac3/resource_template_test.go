@@ -69,3 +69,85 @@ func Test_parseArgs(t *testing.T) {
 		})
 	}
 }
+
+func Test_withProviderOption(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want string
+	}{
+		{
+			name: "constructor without options",
+			expr: `new aws.sqs.Queue(args.Name, { fifoQueue: args.FifoQueue })`,
+			want: `new aws.sqs.Queue(args.Name, { fifoQueue: args.FifoQueue }{{ if .Provider }}, { provider: args.Provider }{{ end }})`,
+		},
+		{
+			name: "constructor with options",
+			expr: `new aws.s3.Bucket(args.Name, {}, { protect: args.protect })`,
+			want: "new aws.s3.Bucket(args.Name, {}, {\n{{- if .Provider }} provider: args.Provider,{{ end }} protect: args.protect })",
+		},
+		{
+			name: "get",
+			expr: `aws.ec2.Vpc.get(args.Name, args.Id)`,
+			want: `aws.ec2.Vpc.get(args.Name, args.Id{{ if .Provider }}, undefined, { provider: args.Provider }{{ end }})`,
+		},
+		{
+			name: "non-aws constructor",
+			expr: `new pulumi_k8s.yaml.ConfigFile(args.Name, { file: args.FilePath })`,
+			want: `new pulumi_k8s.yaml.ConfigFile(args.Name, { file: args.FilePath })`,
+		},
+		{
+			name: "invoke",
+			expr: `pulumi.output(aws.getRegion({}))`,
+			want: `pulumi.output(aws.getRegion({}))`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := sitter.NewParser()
+			parser.SetLanguage(templateTSLang.Sitter)
+			tree, err := parser.ParseCtx(context.TODO(), nil, []byte(tt.expr))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// program > expression_statement > expression
+			expr := tree.RootNode().NamedChild(0).NamedChild(0)
+
+			assert.Equal(t, tt.want, withProviderOption(expr))
+		})
+	}
+}
+
+func Test_exportProperties(t *testing.T) {
+	content := `
+function properties(object: aws.lb.LoadBalancer, args: Args) {
+    return {
+        DnsName: object.dnsName,
+        Arn: object.arn,
+    }
+}
+
+function infraExports(
+    object: ReturnType<typeof create>,
+    args: Args,
+    props: ReturnType<typeof properties>
+) {
+    return {
+        DomainName: object.dnsName,
+        LbArn: props.Arn,
+        Url: object.url,
+    }
+}
+`
+	parser := sitter.NewParser()
+	parser.SetLanguage(templateTSLang.Sitter)
+	tree, err := parser.ParseCtx(context.TODO(), nil, []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, map[string]string{
+		"DomainName": "DnsName",
+		"LbArn":      "Arn",
+	}, exportProperties(tree.RootNode()))
+}
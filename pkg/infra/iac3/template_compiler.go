@@ -15,6 +15,13 @@ type TemplatesCompiler struct {
 
 	graph construct.Graph
 	vars  variables
+
+	// outputSelectors limits which exports are rendered. If empty, all exports are rendered.
+	outputSelectors []OutputSelector
+	// matchedSelectors records which of the outputSelectors matched at least one export
+	matchedSelectors []bool
+	// outputs are the exports rendered by [TemplatesCompiler.RenderResource]
+	outputs []StackOutput
 }
 
 // globalVariables are variables set in the global template and available to all resources
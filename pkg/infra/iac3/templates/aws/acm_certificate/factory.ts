@@ -44,5 +44,7 @@ function create(args: Args): aws.acm.Certificate {
 function properties(object: aws.acm.Certificate, args: Args) {
     return {
         Arn: object.arn,
+        ValidationRecordName: object.domainValidationOptions.apply((o) => o[0].resourceRecordName),
+        ValidationRecordValue: object.domainValidationOptions.apply((o) => o[0].resourceRecordValue),
     }
 }
@@ -0,0 +1,23 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Certificate: aws.acm.Certificate
+    ValidationRecord?: aws.route53.Record
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.acm.CertificateValidation {
+    return new aws.acm.CertificateValidation(args.Name, {
+        certificateArn: args.Certificate.arn,
+        //TMPL {{- if .ValidationRecord }}
+        validationRecordFqdns: [args.ValidationRecord.fqdn],
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.acm.CertificateValidation, args: Args) {
+    return {
+        CertificateArn: object.certificateArn,
+    }
+}
@@ -0,0 +1,27 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    RestApi: aws.apigateway.RestApi
+    Type: string
+    IdentitySource: string
+    UserPools: aws.cognito.UserPool[]
+    AuthorizerFunction: aws.lambda.Function
+    AuthorizerResultTtlInSeconds: number
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigateway.Authorizer {
+    return new aws.apigateway.Authorizer(args.Name, {
+        restApi: args.RestApi.id,
+        type: args.Type,
+        identitySource: args.IdentitySource,
+        //TMPL {{- if .UserPools }}
+        providerArns: args.UserPools.map((pool) => pool.arn),
+        //TMPL {{- end }}
+        //TMPL {{- if .AuthorizerFunction }}
+        authorizerUri: args.AuthorizerFunction.invokeArn,
+        //TMPL {{- end }}
+        authorizerResultTtlInSeconds: args.AuthorizerResultTtlInSeconds,
+    })
+}
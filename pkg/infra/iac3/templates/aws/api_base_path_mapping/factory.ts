@@ -0,0 +1,21 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    DomainName: aws.apigateway.DomainName
+    RestApi: aws.apigateway.RestApi
+    Stage: aws.apigateway.Stage
+    BasePath: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigateway.BasePathMapping {
+    return new aws.apigateway.BasePathMapping(args.Name, {
+        domainName: args.DomainName.domainName,
+        restApi: args.RestApi.id,
+        stageName: args.Stage.stageName,
+        //TMPL {{- if .BasePath }}
+        basePath: args.BasePath,
+        //TMPL {{- end }}
+    })
+}
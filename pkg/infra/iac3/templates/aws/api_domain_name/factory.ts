@@ -0,0 +1,38 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    DomainName: string
+    Certificate: aws.acm.Certificate
+    CertificateValidation?: aws.acm.CertificateValidation
+    EndpointType: string
+    SecurityPolicy: string
+    Tags: ModelCaseWrapper<Record<string, string>>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigateway.DomainName {
+    return new aws.apigateway.DomainName(args.Name, {
+        domainName: args.DomainName,
+        //TMPL {{- if .CertificateValidation }}
+        regionalCertificateArn: args.CertificateValidation.certificateArn,
+        //TMPL {{- else }}
+        regionalCertificateArn: args.Certificate.arn,
+        //TMPL {{- end }}
+        endpointConfiguration: {
+            types: [args.EndpointType],
+        },
+        securityPolicy: args.SecurityPolicy,
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.apigateway.DomainName, args: Args) {
+    return {
+        RegionalDomainName: object.regionalDomainName,
+        RegionalZoneId: object.regionalZoneId,
+    }
+}
@@ -15,6 +15,8 @@ interface Args {
     Uri: pulumi.Output<string>
     Route: string
     Target: pulumi.Resource
+    Credentials: aws.iam.Role
+    RequestTemplates: ModelCaseWrapper<Record<string, string>>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -41,6 +43,12 @@ function create(args: Args): aws.apigateway.Integration {
             //TMPL {{- if .RequestParameters }}
             requestParameters: args.RequestParameters,
             //TMPL {{- end }}
+            //TMPL {{- if .Credentials }}
+            credentials: args.Credentials.arn,
+            //TMPL {{- end }}
+            //TMPL {{- if .RequestTemplates }}
+            requestTemplates: args.RequestTemplates,
+            //TMPL {{- end }}
         },
         { parent: args.Method }
     )
@@ -8,6 +8,7 @@ interface Args {
     HttpMethod: string
     RequestParameters: ModelCaseWrapper<Record<string, boolean>>
     Authorization: string
+    Authorizer: aws.apigateway.Authorizer
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -23,6 +24,9 @@ function create(args: Args): aws.apigateway.Method {
             //TMPL {{- end }}
             httpMethod: args.HttpMethod,
             authorization: args.Authorization,
+            //TMPL {{- if .Authorizer }}
+            authorizerId: args.Authorizer.id,
+            //TMPL {{- end }}
             //TMPL {{- if .RequestParameters }}
             requestParameters: args.RequestParameters,
             //TMPL {{- end }}
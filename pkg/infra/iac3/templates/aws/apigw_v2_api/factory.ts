@@ -0,0 +1,47 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    ProtocolType: string
+    RouteSelectionExpression: string
+    CorsConfiguration: Record<string, any>
+    Description: string
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Api {
+    return new aws.apigatewayv2.Api(args.Name, {
+        protocolType: args.ProtocolType,
+        //TMPL {{- if .RouteSelectionExpression }}
+        routeSelectionExpression: args.RouteSelectionExpression,
+        //TMPL {{- end }}
+        //TMPL {{- if .CorsConfiguration }}
+        corsConfiguration: args.CorsConfiguration,
+        //TMPL {{- end }}
+        //TMPL {{- if .Description }}
+        description: args.Description,
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.apigatewayv2.Api, args: Args) {
+    return {
+        ApiEndpoint: object.apiEndpoint,
+        ChildResources: pulumi.interpolate`${object.executionArn}/*/*`,
+    }
+}
+
+function infraExports(
+    object: ReturnType<typeof create>,
+    args: Args,
+    props: ReturnType<typeof properties>
+) {
+    return {
+        ApiEndpoint: object.apiEndpoint,
+    }
+}
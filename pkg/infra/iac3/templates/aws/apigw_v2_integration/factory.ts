@@ -0,0 +1,38 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Api: aws.apigatewayv2.Api
+    IntegrationType: string
+    IntegrationMethod: string
+    IntegrationUri: pulumi.Output<string>
+    PayloadFormatVersion: string
+    ConnectionType: string
+    VpcLink: aws.apigatewayv2.VpcLink
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Integration {
+    return new aws.apigatewayv2.Integration(
+        args.Name,
+        {
+            apiId: args.Api.id,
+            integrationType: args.IntegrationType,
+            //TMPL {{- if .IntegrationMethod }}
+            integrationMethod: args.IntegrationMethod,
+            //TMPL {{- end }}
+            //TMPL {{- if .IntegrationUri }}
+            integrationUri: args.IntegrationUri,
+            //TMPL {{- end }}
+            //TMPL {{- if .PayloadFormatVersion }}
+            payloadFormatVersion: args.PayloadFormatVersion,
+            //TMPL {{- end }}
+            connectionType: args.ConnectionType,
+            //TMPL {{- if .VpcLink }}
+            connectionId: args.VpcLink.id,
+            //TMPL {{- end }}
+        },
+        { parent: args.Api }
+    )
+}
@@ -0,0 +1,26 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Api: aws.apigatewayv2.Api
+    RouteKey: string
+    Integration: aws.apigatewayv2.Integration
+    AuthorizationType: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.Route {
+    return new aws.apigatewayv2.Route(
+        args.Name,
+        {
+            apiId: args.Api.id,
+            routeKey: args.RouteKey,
+            //TMPL {{- if .Integration }}
+            target: pulumi.interpolate`integrations/${args.Integration.id}`,
+            //TMPL {{- end }}
+            authorizationType: args.AuthorizationType,
+        },
+        { parent: args.Api }
+    )
+}
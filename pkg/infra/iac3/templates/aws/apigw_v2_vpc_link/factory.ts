@@ -0,0 +1,20 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    SecurityGroups: aws.ec2.SecurityGroup[]
+    Subnets: aws.ec2.Subnet[]
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.apigatewayv2.VpcLink {
+    return new aws.apigatewayv2.VpcLink(args.Name, {
+        name: args.Name,
+        securityGroupIds: args.SecurityGroups.map((sg) => sg.id),
+        subnetIds: args.Subnets.map((subnet) => subnet.id),
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
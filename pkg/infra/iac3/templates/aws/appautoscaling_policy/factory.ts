@@ -0,0 +1,93 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    ScalingTarget: aws.appautoscaling.Target
+    PolicyType: string
+    TargetTrackingConfiguration: ModelCaseWrapper<Record<string, any>>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.appautoscaling.Policy {
+    return new aws.appautoscaling.Policy(args.Name, {
+        policyType: args.PolicyType,
+        serviceNamespace: args.ScalingTarget.serviceNamespace,
+        scalableDimension: args.ScalingTarget.scalableDimension,
+        resourceId: args.ScalingTarget.resourceId,
+        targetTrackingScalingPolicyConfiguration: {
+            //TMPL targetValue: {{ .TargetTrackingConfiguration.TargetValue }},
+            //TMPL scaleInCooldown: {{ .TargetTrackingConfiguration.ScaleInCooldown }},
+            //TMPL scaleOutCooldown: {{ .TargetTrackingConfiguration.ScaleOutCooldown }},
+            //TMPL disableScaleIn: {{ .TargetTrackingConfiguration.DisableScaleIn }},
+            //TMPL {{- if .TargetTrackingConfiguration.PredefinedMetricType }}
+            predefinedMetricSpecification: {
+                //TMPL predefinedMetricType: {{ .TargetTrackingConfiguration.PredefinedMetricType }},
+                //TMPL {{- if .TargetTrackingConfiguration.TargetGroup }}
+                //TMPL resourceLabel: pulumi.interpolate`${ {{- .TargetTrackingConfiguration.LoadBalancer }}.arnSuffix}/${ {{- .TargetTrackingConfiguration.TargetGroup }}.arnSuffix}`,
+                //TMPL {{- end }}
+            },
+            //TMPL {{- else }}
+            customizedMetricSpecification: {
+                metrics: [
+                    {
+                        id: 'visible',
+                        metricStat: {
+                            metric: {
+                                namespace: 'AWS/SQS',
+                                metricName: 'ApproximateNumberOfMessagesVisible',
+                                dimensions: [
+                                    {
+                                        name: 'QueueName',
+                                        //TMPL value: {{ .TargetTrackingConfiguration.Queue }}.name,
+                                    },
+                                ],
+                            },
+                            stat: 'Sum',
+                        },
+                        returnData: false,
+                    },
+                    {
+                        id: 'running',
+                        metricStat: {
+                            metric: {
+                                namespace: 'ECS/ContainerInsights',
+                                metricName: 'RunningTaskCount',
+                                dimensions: [
+                                    {
+                                        name: 'ClusterName',
+                                        value: args.ScalingTarget.resourceId.apply(
+                                            (id) => id.split('/')[1]
+                                        ),
+                                    },
+                                    {
+                                        name: 'ServiceName',
+                                        value: args.ScalingTarget.resourceId.apply(
+                                            (id) => id.split('/')[2]
+                                        ),
+                                    },
+                                ],
+                            },
+                            stat: 'Average',
+                        },
+                        returnData: false,
+                    },
+                    {
+                        id: 'backlog',
+                        expression: 'visible / running',
+                        label: 'Backlog per task',
+                        returnData: true,
+                    },
+                ],
+            },
+            //TMPL {{- end }}
+        },
+    })
+}
+
+function properties(object: aws.appautoscaling.Policy, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
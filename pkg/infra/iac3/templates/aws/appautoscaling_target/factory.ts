@@ -0,0 +1,28 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    ServiceNamespace: string
+    ScalableDimension: string
+    ResourceId: pulumi.Input<string>
+    MinCapacity: number
+    MaxCapacity: number
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.appautoscaling.Target {
+    return new aws.appautoscaling.Target(args.Name, {
+        serviceNamespace: args.ServiceNamespace,
+        scalableDimension: args.ScalableDimension,
+        resourceId: args.ResourceId,
+        minCapacity: args.MinCapacity,
+        maxCapacity: args.MaxCapacity,
+    })
+}
+
+function properties(object: aws.appautoscaling.Target, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
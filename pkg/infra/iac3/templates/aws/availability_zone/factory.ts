@@ -4,13 +4,19 @@ import * as pulumi from '@pulumi/pulumi'
 interface Args {
     Name: string
     Index: number
+    Provider?: aws.Provider
 }
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): pulumi.Output<pulumi.UnwrappedObject<aws.GetAvailabilityZonesArgs>> {
     return pulumi.output(
-        aws.getAvailabilityZones({
-            state: 'available',
-        })
+        aws.getAvailabilityZones(
+            {
+                state: 'available',
+            },
+            //TMPL {{- if .Provider }}
+            { provider: args.Provider }
+            //TMPL {{- end }}
+        )
     ).names[args.Index]
 }
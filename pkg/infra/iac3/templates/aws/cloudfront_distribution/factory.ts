@@ -3,12 +3,16 @@ import * as pulumi from '@pulumi/pulumi'
 
 interface Args {
     Name: string
+    Aliases: string[]
+    Certificate: aws.acm.Certificate
+    CertificateValidation?: aws.acm.CertificateValidation
     Origins: aws.types.input.cloudfront.DistributionOrigin[]
     CloudfrontDefaultCertificate: boolean
     Enabled: boolean
     DefaultCacheBehavior: aws.types.input.cloudfront.DistributionDefaultCacheBehavior
     Restrictions: aws.types.input.cloudfront.DistributionRestrictions
     DefaultRootObject: string
+    WebAclId: pulumi.Input<string>
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -16,9 +20,23 @@ function create(args: Args): aws.cloudfront.Distribution {
     return new aws.cloudfront.Distribution(args.Name, {
         origins: args.Origins,
         enabled: args.Enabled,
+        //TMPL {{- if .Aliases }}
+        aliases: args.Aliases,
+        //TMPL {{- end }}
+        //TMPL {{- if .Certificate }}
+        viewerCertificate: {
+            //TMPL {{- if .CertificateValidation }}
+            acmCertificateArn: args.CertificateValidation.certificateArn,
+            //TMPL {{- else }}
+            acmCertificateArn: args.Certificate.arn,
+            //TMPL {{- end }}
+            sslSupportMethod: 'sni-only',
+        },
+        //TMPL {{- else }}
         viewerCertificate: {
             cloudfrontDefaultCertificate: args.CloudfrontDefaultCertificate,
         },
+        //TMPL {{- end }}
         //TMPL {{- if (index .DefaultCacheBehavior "targetOriginId") }}
         defaultCacheBehavior: args.DefaultCacheBehavior,
         //TMPL {{- else }}
@@ -31,10 +49,18 @@ function create(args: Args): aws.cloudfront.Distribution {
         //TMPL {{- if .DefaultRootObject }}
         defaultRootObject: args.DefaultRootObject,
         //TMPL {{- end }}
+        //TMPL {{- if .WebAclId }}
+        webAclId: args.WebAclId,
+        //TMPL {{- end }}
     })
 }
 
-function properties(object: ReturnType<typeof create>, args: Args) {}
+function properties(object: ReturnType<typeof create>, args: Args) {
+    return {
+        DomainName: object.domainName,
+        HostedZoneId: object.hostedZoneId,
+    }
+}
 
 function infraExports(
     object: ReturnType<typeof create>,
@@ -0,0 +1,61 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    AlarmDescription: string
+    MetricName: string
+    Namespace: string
+    Statistic: string
+    Period: number
+    EvaluationPeriods: number
+    DatapointsToAlarm: number
+    Threshold: number
+    ComparisonOperator: string
+    Dimensions: ModelCaseWrapper<Record<string, string>>
+    TreatMissingData: string
+    ActionsEnabled: boolean
+    AlarmActions: aws.sns.Topic[]
+    OKActions: aws.sns.Topic[]
+    Tags: ModelCaseWrapper<Record<string, string>>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.MetricAlarm {
+    return new aws.cloudwatch.MetricAlarm(args.Name, {
+        name: args.Name,
+        //TMPL {{- if .AlarmDescription }}
+        alarmDescription: args.AlarmDescription,
+        //TMPL {{- end }}
+        metricName: args.MetricName,
+        namespace: args.Namespace,
+        statistic: args.Statistic,
+        period: args.Period,
+        evaluationPeriods: args.EvaluationPeriods,
+        //TMPL {{- if .DatapointsToAlarm }}
+        datapointsToAlarm: args.DatapointsToAlarm,
+        //TMPL {{- end }}
+        threshold: args.Threshold,
+        comparisonOperator: args.ComparisonOperator,
+        //TMPL {{- if .Dimensions }}
+        dimensions: args.Dimensions,
+        //TMPL {{- end }}
+        treatMissingData: args.TreatMissingData,
+        actionsEnabled: args.ActionsEnabled,
+        //TMPL {{- if .AlarmActions }}
+        alarmActions: args.AlarmActions.map((topic) => topic.arn),
+        //TMPL {{- end }}
+        //TMPL {{- if .OKActions }}
+        okActions: args.OKActions.map((topic) => topic.arn),
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.cloudwatch.MetricAlarm, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
@@ -0,0 +1,34 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Widgets: {
+        title: string
+        alarms: aws.cloudwatch.MetricAlarm[]
+        width: number
+        height: number
+    }[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.Dashboard {
+    return new aws.cloudwatch.Dashboard(args.Name, {
+        dashboardName: args.Name,
+        dashboardBody: pulumi.jsonStringify({
+            //TMPL {{- if .Widgets }}
+            widgets: args.Widgets.map((widget) => ({
+                type: 'alarm',
+                width: widget.width,
+                height: widget.height,
+                properties: {
+                    title: widget.title,
+                    alarms: widget.alarms.map((alarm) => alarm.arn),
+                },
+            })),
+            //TMPL {{- else }}
+            widgets: [],
+            //TMPL {{- end }}
+        }),
+    })
+}
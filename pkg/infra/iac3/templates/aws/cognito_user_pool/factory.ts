@@ -0,0 +1,37 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    AutoVerifiedAttributes: string[]
+    UsernameAttributes: string[]
+    MfaConfiguration: string
+    PasswordPolicy: Record<string, any>
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cognito.UserPool {
+    return new aws.cognito.UserPool(args.Name, {
+        name: args.Name,
+        //TMPL {{- if .AutoVerifiedAttributes }}
+        autoVerifiedAttributes: args.AutoVerifiedAttributes,
+        //TMPL {{- end }}
+        //TMPL {{- if .UsernameAttributes }}
+        usernameAttributes: args.UsernameAttributes,
+        //TMPL {{- end }}
+        mfaConfiguration: args.MfaConfiguration,
+        //TMPL {{- if .PasswordPolicy }}
+        passwordPolicy: args.PasswordPolicy,
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.cognito.UserPool, args: Args) {
+    return {
+        Arn: object.arn,
+        Id: object.id,
+    }
+}
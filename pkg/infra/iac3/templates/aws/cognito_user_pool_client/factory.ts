@@ -0,0 +1,49 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    UserPool: aws.cognito.UserPool
+    GenerateSecret: boolean
+    ExplicitAuthFlows: string[]
+    AllowedOAuthFlowsUserPoolClient: boolean
+    AllowedOAuthFlows: string[]
+    AllowedOAuthScopes: string[]
+    CallbackURLs: pulumi.Input<string>[]
+    SupportedIdentityProviders: string[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cognito.UserPoolClient {
+    return new aws.cognito.UserPoolClient(args.Name, {
+        name: args.Name,
+        userPoolId: args.UserPool.id,
+        //TMPL {{- if .GenerateSecret }}
+        generateSecret: args.GenerateSecret,
+        //TMPL {{- end }}
+        //TMPL {{- if .ExplicitAuthFlows }}
+        explicitAuthFlows: args.ExplicitAuthFlows,
+        //TMPL {{- end }}
+        //TMPL {{- if .AllowedOAuthFlowsUserPoolClient }}
+        allowedOauthFlowsUserPoolClient: args.AllowedOAuthFlowsUserPoolClient,
+        //TMPL {{- end }}
+        //TMPL {{- if .AllowedOAuthFlows }}
+        allowedOauthFlows: args.AllowedOAuthFlows,
+        //TMPL {{- end }}
+        //TMPL {{- if .AllowedOAuthScopes }}
+        allowedOauthScopes: args.AllowedOAuthScopes,
+        //TMPL {{- end }}
+        //TMPL {{- if .CallbackURLs }}
+        callbackUrls: args.CallbackURLs,
+        //TMPL {{- end }}
+        //TMPL {{- if .SupportedIdentityProviders }}
+        supportedIdentityProviders: args.SupportedIdentityProviders,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.cognito.UserPoolClient, args: Args) {
+    return {
+        Id: object.id,
+    }
+}
@@ -0,0 +1,15 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Domain: string
+    UserPool: aws.cognito.UserPool
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cognito.UserPoolDomain {
+    return new aws.cognito.UserPoolDomain(args.Name, {
+        domain: args.Domain,
+        userPoolId: args.UserPool.id,
+    })
+}
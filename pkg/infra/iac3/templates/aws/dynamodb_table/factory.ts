@@ -1,7 +1,7 @@
 import * as aws from '@pulumi/aws'
 import * as pulumi from '@pulumi/pulumi'
 import * as awsInputs from '@pulumi/aws/types/input'
-import { TemplateWrapper } from '../../wrappers'
+import { ModelCaseWrapper, TemplateWrapper } from '../../wrappers'
 
 interface Args {
     Name: string
@@ -9,6 +9,7 @@ interface Args {
     HashKey: string
     RangeKey: string
     BillingMode: string
+    StreamSpecification?: ModelCaseWrapper<Record<string, any>>
     protect: boolean
 }
 
@@ -22,6 +23,12 @@ function create(args: Args): aws.dynamodb.Table {
             rangeKey: args.RangeKey,
             //TMPL {{- end }}
             billingMode: args.BillingMode,
+            //TMPL {{- if .StreamSpecification.StreamEnabled }}
+            //TMPL streamEnabled: {{ .StreamSpecification.StreamEnabled }},
+            //TMPL {{- if .StreamSpecification.StreamViewType }}
+            //TMPL streamViewType: {{ .StreamSpecification.StreamViewType }},
+            //TMPL {{- end }}
+            //TMPL {{- end }}
         },
         { protect: args.protect }
     )
@@ -35,5 +42,6 @@ function properties(object: aws.dynamodb.Table, args: Args) {
         DynamoTableExportArn: pulumi.interpolate`${object.arn}/export/*`,
         DynamoTableIndexArn: pulumi.interpolate`${object.arn}/index/*`,
         Name: object.name,
+        StreamArn: object.streamArn,
     }
 }
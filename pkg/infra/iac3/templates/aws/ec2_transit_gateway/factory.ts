@@ -0,0 +1,43 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    AmazonSideAsn?: number
+    AutoAcceptSharedAttachments: string
+    DefaultRouteTableAssociation: string
+    DefaultRouteTablePropagation: string
+    DnsSupport: string
+    Description?: string
+    Tags: Record<string, string>
+    Id?: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.ec2transitgateway.TransitGateway {
+    return new aws.ec2transitgateway.TransitGateway(args.Name, {
+        //TMPL {{- if .AmazonSideAsn }}
+        amazonSideAsn: args.AmazonSideAsn,
+        //TMPL {{- end }}
+        autoAcceptSharedAttachments: args.AutoAcceptSharedAttachments,
+        defaultRouteTableAssociation: args.DefaultRouteTableAssociation,
+        defaultRouteTablePropagation: args.DefaultRouteTablePropagation,
+        dnsSupport: args.DnsSupport,
+        //TMPL {{- if .Description }}
+        description: args.Description,
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.ec2transitgateway.TransitGateway, args: Args) {
+    return {
+        Id: object.id,
+        Arn: object.arn,
+    }
+}
+
+function importResource(args: Args): aws.ec2transitgateway.TransitGateway {
+    return aws.ec2transitgateway.TransitGateway.get(args.Name, args.Id)
+}
@@ -0,0 +1,15 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Attachment: aws.ec2transitgateway.VpcAttachment
+    TransitGatewayRouteTableId: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.ec2transitgateway.RouteTablePropagation {
+    return new aws.ec2transitgateway.RouteTablePropagation(args.Name, {
+        transitGatewayAttachmentId: args.Attachment.id,
+        transitGatewayRouteTableId: args.TransitGatewayRouteTableId,
+    })
+}
@@ -0,0 +1,35 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    TransitGateway: aws.ec2transitgateway.TransitGateway
+    Vpc: aws.ec2.Vpc
+    Subnets: aws.ec2.Subnet[]
+    DnsSupport: string
+    ApplianceModeSupport: string
+    TransitGatewayDefaultRouteTableAssociation: boolean
+    TransitGatewayDefaultRouteTablePropagation: boolean
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.ec2transitgateway.VpcAttachment {
+    return new aws.ec2transitgateway.VpcAttachment(args.Name, {
+        transitGatewayId: args.TransitGateway.id,
+        vpcId: args.Vpc.id,
+        subnetIds: args.Subnets.map((subnet) => subnet.id),
+        dnsSupport: args.DnsSupport,
+        applianceModeSupport: args.ApplianceModeSupport,
+        transitGatewayDefaultRouteTableAssociation: args.TransitGatewayDefaultRouteTableAssociation,
+        transitGatewayDefaultRouteTablePropagation: args.TransitGatewayDefaultRouteTablePropagation,
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.ec2transitgateway.VpcAttachment, args: Args) {
+    return {
+        Id: object.id,
+    }
+}
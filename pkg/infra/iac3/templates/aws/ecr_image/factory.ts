@@ -2,6 +2,7 @@ import * as pulumi from '@pulumi/pulumi'
 import * as docker from '@pulumi/docker'
 import * as aws from '@pulumi/aws'
 import * as command from '@pulumi/command'
+import { ModelCaseWrapper } from '../../wrappers'
 
 interface Args {
     Name: string
@@ -10,6 +11,7 @@ interface Args {
     Context: string
     Dockerfile: string
     BaseImage: string
+    BuildArgs?: ModelCaseWrapper<Record<string, string>>
     dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
 }
 
@@ -29,6 +31,9 @@ function create(args: Args): docker.Image {
                     context: args.Context,
                     dockerfile: args.Dockerfile,
                     platform: 'linux/amd64',
+                    //TMPL {{- if .BuildArgs }}
+                    args: args.BuildArgs,
+                    //TMPL {{- end }}
                 },
                 skipPush: true,
                 imageName: pulumi.interpolate`${args.Repo.repositoryUrl}:{{ if .Tag }}${args.Tag}-{{ end }}base`,
@@ -53,6 +58,9 @@ function create(args: Args): docker.Image {
                     context: args.Context,
                     dockerfile: args.Dockerfile,
                     platform: 'linux/amd64',
+                    //TMPL {{- if .BuildArgs }}
+                    args: args.BuildArgs,
+                    //TMPL {{- end }}
                 },
                 registry: aws.ecr
                     .getAuthorizationTokenOutput(
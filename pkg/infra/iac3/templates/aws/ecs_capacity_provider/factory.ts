@@ -0,0 +1,19 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Cluster: aws.ecs.Cluster
+    CapacityProviders: string[]
+    DefaultCapacityProviderStrategy: aws.types.input.ecs.ClusterCapacityProvidersDefaultCapacityProviderStrategy[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.ecs.ClusterCapacityProviders {
+    return new aws.ecs.ClusterCapacityProviders(args.Name, {
+        clusterName: args.Cluster.name,
+        capacityProviders: args.CapacityProviders,
+        //TMPL {{- if .DefaultCapacityProviderStrategy }}
+        defaultCapacityProviderStrategies: args.DefaultCapacityProviderStrategy,
+        //TMPL {{- end }}
+    })
+}
@@ -2,9 +2,25 @@ import * as aws from '@pulumi/aws'
 
 interface Args {
     Name: string
+    ContainerInsights?: boolean
 }
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): aws.ecs.Cluster {
-    return new aws.ecs.Cluster(args.Name, {})
+    return new aws.ecs.Cluster(args.Name, {
+        //TMPL {{- if .ContainerInsights }}
+        settings: [
+            {
+                name: 'containerInsights',
+                value: 'enabled',
+            },
+        ],
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.ecs.Cluster, args: Args) {
+    return {
+        Arn: object.arn,
+    }
 }
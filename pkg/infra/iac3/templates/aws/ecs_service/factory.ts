@@ -6,6 +6,7 @@ import { TemplateWrapper } from '../../wrappers'
 
 interface Args {
     AssignPublicIp: Promise<boolean> | OutputInstance<boolean> | boolean
+    CapacityProviderStrategy: aws.types.input.ecs.ServiceCapacityProviderStrategy[]
     DeploymentCircuitBreaker:
         | Promise<awsInputs.ecs.ServiceDeploymentCircuitBreaker>
         | OutputInstance<awsInputs.ecs.ServiceDeploymentCircuitBreaker>
@@ -27,7 +28,11 @@ function create(args: Args): aws.ecs.Service {
     return new aws.ecs.Service(
         args.Name,
         {
+            //TMPL {{- if .CapacityProviderStrategy }}
+            capacityProviderStrategies: args.CapacityProviderStrategy,
+            //TMPL {{- else }}
             launchType: args.LaunchType,
+            //TMPL {{- end }}
             cluster: args.Cluster.arn,
             //TMPL {{- if .DeploymentCircuitBreaker }}
             //TMPL deploymentCircuitBreaker: {
@@ -59,3 +64,9 @@ function create(args: Args): aws.ecs.Service {
         { dependsOn: args.dependsOn }
     )
 }
+
+function properties(object: aws.ecs.Service, args: Args) {
+    return {
+        ScalingResourceId: pulumi.interpolate`service/${args.Cluster.name}/${object.name}`,
+    }
+}
@@ -67,3 +67,9 @@ function create(args: Args): aws.ecs.TaskDefinition {
         ]),
     })
 }
+
+function properties(object: aws.ecs.TaskDefinition, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
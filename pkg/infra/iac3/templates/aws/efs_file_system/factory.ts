@@ -8,7 +8,6 @@ interface Args {
     ProvisionedThroughputInMibps: number
     PerformanceMode: string
     Name: string
-    AvailabilityZoneName?: string
     KmsKey?: aws.kms.Key
     Encrypted?: Promise<boolean> | pulumi.OutputInstance<boolean> | boolean
     CreationToken?: Promise<string> | pulumi.OutputInstance<string> | string
@@ -20,9 +19,6 @@ function create(args: Args): aws.efs.FileSystem {
     return new aws.efs.FileSystem(
         args.Name,
         {
-            //TMPL {{- if .AvailabilityZoneName }}
-            availabilityZoneName: args.AvailabilityZoneName,
-            //TMPL {{- end }}
             //TMPL {{- if .CreationToken }}
             creationToken: args.CreationToken,
             //TMPL {{- end }}
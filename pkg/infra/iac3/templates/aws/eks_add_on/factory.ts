@@ -3,8 +3,8 @@ import * as pulumi from '@pulumi/pulumi'
 
 interface Args {
     Name: string
-    AddonName: string
-    ClusterName: pulumi.Input<string>
+    AddOnName: string
+    Cluster: aws.eks.Cluster
     Role: aws.iam.Role
 }
 
@@ -0,0 +1,88 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Description: string
+    Engine: string
+    EngineVersion: string
+    NodeType: string
+    Port: number
+    ClusterMode: boolean
+    NumCacheClusters?: number
+    NumNodeGroups?: number
+    ReplicasPerNodeGroup?: number
+    ParameterGroupName?: string
+    AutomaticFailoverEnabled: boolean
+    MultiAzEnabled: boolean
+    AtRestEncryptionEnabled: boolean
+    KmsKey?: aws.kms.Key
+    TransitEncryptionEnabled: boolean
+    AuthToken?: aws.secretsmanager.SecretVersion
+    SnapshotRetentionLimit: number
+    CloudwatchGroup: aws.cloudwatch.LogGroup
+    SubnetGroup: aws.elasticache.SubnetGroup
+    SecurityGroups: aws.ec2.SecurityGroup[]
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.elasticache.ReplicationGroup {
+    return new aws.elasticache.ReplicationGroup(args.Name, {
+        description: args.Description,
+        engine: args.Engine,
+        engineVersion: args.EngineVersion,
+        nodeType: args.NodeType,
+        port: args.Port,
+        //TMPL {{- if .ClusterMode }}
+        numNodeGroups: args.NumNodeGroups,
+        replicasPerNodeGroup: args.ReplicasPerNodeGroup,
+        //TMPL {{- else }}
+        numCacheClusters: args.NumCacheClusters,
+        //TMPL {{- end }}
+        //TMPL {{- if .ParameterGroupName }}
+        parameterGroupName: args.ParameterGroupName,
+        //TMPL {{- end }}
+        automaticFailoverEnabled: args.AutomaticFailoverEnabled,
+        multiAzEnabled: args.MultiAzEnabled,
+        atRestEncryptionEnabled: args.AtRestEncryptionEnabled,
+        //TMPL {{- if .KmsKey }}
+        kmsKeyId: args.KmsKey.arn,
+        //TMPL {{- end }}
+        transitEncryptionEnabled: args.TransitEncryptionEnabled,
+        //TMPL {{- if .AuthToken }}
+        authToken: args.AuthToken.secretString,
+        //TMPL {{- end }}
+        snapshotRetentionLimit: args.SnapshotRetentionLimit,
+        logDeliveryConfigurations: [
+            {
+                destination: args.CloudwatchGroup.name,
+                destinationType: 'cloudwatch-logs',
+                logFormat: 'text',
+                logType: 'slow-log',
+            },
+            {
+                destination: args.CloudwatchGroup.name,
+                destinationType: 'cloudwatch-logs',
+                logFormat: 'json',
+                logType: 'engine-log',
+            },
+        ],
+        subnetGroupName: args.SubnetGroup.name,
+        securityGroupIds: args.SecurityGroups.map((sg) => sg.id),
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.elasticache.ReplicationGroup, args: Args) {
+    return {
+        //TMPL {{- if .ClusterMode }}
+        Endpoint: object.configurationEndpointAddress,
+        //TMPL {{- else }}
+        Endpoint: object.primaryEndpointAddress,
+        //TMPL {{- end }}
+        ReaderEndpoint: object.readerEndpointAddress,
+        Arn: object.arn,
+    }
+}
@@ -0,0 +1,30 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    Tags?: ModelCaseWrapper<Record<string, string>>
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.EventBus {
+    return new aws.cloudwatch.EventBus(
+        args.Name,
+        {
+            name: args.Name,
+            //TMPL {{- if .Tags }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        },
+        //TMPL {{- if .protect }}
+        { protect: args.protect }
+        //TMPL {{- end }}
+    )
+}
+
+function properties(object: aws.cloudwatch.EventBus, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
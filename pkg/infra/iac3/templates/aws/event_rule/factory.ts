@@ -0,0 +1,42 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    EventBus?: aws.cloudwatch.EventBus
+    EventPattern?: string
+    ScheduleExpression?: string
+    Description?: string
+    State?: string
+    Tags?: ModelCaseWrapper<Record<string, string>>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.EventRule {
+    return new aws.cloudwatch.EventRule(args.Name, {
+        //TMPL {{- if .EventBus }}
+        eventBusName: args.EventBus.name,
+        //TMPL {{- end }}
+        //TMPL {{- if .EventPattern }}
+        eventPattern: args.EventPattern,
+        //TMPL {{- end }}
+        //TMPL {{- if .ScheduleExpression }}
+        scheduleExpression: args.ScheduleExpression,
+        //TMPL {{- end }}
+        //TMPL {{- if .Description }}
+        description: args.Description,
+        //TMPL {{- end }}
+        //TMPL {{- if .State }}
+        state: args.State,
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.cloudwatch.EventRule, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
@@ -0,0 +1,45 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Rule: aws.cloudwatch.EventRule
+    Arn: pulumi.Output<string>
+    Input?: string
+    Role?: aws.iam.Role
+    TaskDefinition?: aws.ecs.TaskDefinition
+    TaskCount?: number
+    LaunchType?: string
+    AssignPublicIp?: boolean
+    Subnets?: aws.ec2.Subnet[]
+    SecurityGroups?: aws.ec2.SecurityGroup[]
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.EventTarget {
+    return new aws.cloudwatch.EventTarget(args.Name, {
+        rule: args.Rule.name,
+        eventBusName: args.Rule.eventBusName,
+        arn: args.Arn,
+        //TMPL {{- if .Input }}
+        input: args.Input,
+        //TMPL {{- end }}
+        //TMPL {{- if .Role }}
+        roleArn: args.Role.arn,
+        //TMPL {{- end }}
+        //TMPL {{- if .TaskDefinition }}
+        ecsTarget: {
+            taskDefinitionArn: args.TaskDefinition.arn,
+            taskCount: args.TaskCount,
+            launchType: args.LaunchType,
+            networkConfiguration: {
+                subnets: args.Subnets.map((subnet) => subnet.id),
+                securityGroups: args.SecurityGroups.map((sg) => sg.id),
+                //TMPL {{- if .AssignPublicIp }}
+                assignPublicIp: args.AssignPublicIp,
+                //TMPL {{- end }}
+            },
+        },
+        //TMPL {{- end }}
+    })
+}
@@ -8,7 +8,6 @@ interface Args {
     AssumeRolePolicyDoc: ModelCaseWrapper<string>
     InlinePolicies: TemplateWrapper<pulumi.Input<pulumi.Input<awsInputs.iam.RoleInlinePolicy>[]>>
     ManagedPolicies: pulumi.Output<string>[]
-    AwsManagedPolicies: string[]
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -18,15 +17,8 @@ function create(args: Args): aws.iam.Role {
         //TMPL {{- if .InlinePolicies }}
         inlinePolicies: args.InlinePolicies,
         //TMPL {{- end }}
-        //TMPL {{- if or .ManagedPolicies .AwsManagedPolicies }}
-        managedPolicyArns: [
-            //TMPL {{- if .ManagedPolicies }}
-            ...args.ManagedPolicies,
-            //TMPL {{- end }}
-            //TMPL {{- if .AwsManagedPolicies }}
-            ...args.AwsManagedPolicies,
-            //TMPL {{- end }}
-        ],
+        //TMPL {{- if .ManagedPolicies }}
+        managedPolicyArns: [...args.ManagedPolicies],
         //TMPL {{- end }}
     })
 }
@@ -0,0 +1,51 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    StreamModeDetails?: ModelCaseWrapper<Record<string, string>>
+    ShardCount?: number
+    RetentionPeriodHours?: number
+    StreamEncryption?: ModelCaseWrapper<Record<string, string>>
+    Tags?: ModelCaseWrapper<Record<string, string>>
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.kinesis.Stream {
+    return new aws.kinesis.Stream(
+        args.Name,
+        {
+            name: args.Name,
+            //TMPL {{- if .StreamModeDetails }}
+            //TMPL streamModeDetails: {
+            //TMPL     streamMode: {{ .StreamModeDetails.StreamMode }},
+            //TMPL },
+            //TMPL {{- end }}
+            //TMPL {{- if .ShardCount }}
+            shardCount: args.ShardCount,
+            //TMPL {{- end }}
+            //TMPL {{- if .RetentionPeriodHours }}
+            retentionPeriod: args.RetentionPeriodHours,
+            //TMPL {{- end }}
+            //TMPL {{- if .StreamEncryption }}
+            //TMPL encryptionType: {{ .StreamEncryption.EncryptionType }},
+            //TMPL {{- if .StreamEncryption.KeyId }}
+            //TMPL kmsKeyId: {{ .StreamEncryption.KeyId }},
+            //TMPL {{- end }}
+            //TMPL {{- end }}
+            //TMPL {{- if .Tags }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        },
+        //TMPL {{- if .protect }}
+        { protect: args.protect }
+        //TMPL {{- end }}
+    )
+}
+
+function properties(object: aws.kinesis.Stream, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
@@ -0,0 +1,15 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    AliasName: string
+    TargetKey: aws.kms.Key
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.kms.Alias {
+    return new aws.kms.Alias(args.Name, {
+        name: args.AliasName,
+        targetKeyId: args.TargetKey.keyId,
+    })
+}
@@ -0,0 +1,82 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+import { accountId, region } from '../../globals'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    Description?: string
+    EnableKeyRotation: boolean
+    KeyUsage: string
+    KeySpec: string
+    MultiRegion: boolean
+    PendingWindowInDays: number
+    KeyPolicy?: ModelCaseWrapper<Record<string, any>>
+    ServicePrincipals?: string[]
+    Tags?: ModelCaseWrapper<Record<string, string>>
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.kms.Key {
+    return new aws.kms.Key(
+        args.Name,
+        {
+            //TMPL {{- if .Description }}
+            description: args.Description,
+            //TMPL {{- end }}
+            enableKeyRotation: args.EnableKeyRotation,
+            keyUsage: args.KeyUsage,
+            keySpec: args.KeySpec,
+            multiRegion: args.MultiRegion,
+            deletionWindowInDays: args.PendingWindowInDays,
+            policy: pulumi.jsonStringify({
+                Version: '2012-10-17',
+                Statement: [
+                    // Without this statement, IAM policies cannot grant access to the key
+                    {
+                        Effect: 'Allow',
+                        Principal: { AWS: pulumi.interpolate`arn:aws:iam::${accountId.accountId}:root` },
+                        Action: 'kms:*',
+                        Resource: '*',
+                    },
+                    //TMPL {{- if .ServicePrincipals }}
+                    {
+                        Effect: 'Allow',
+                        Principal: {
+                            // CloudWatch Logs is the only service whose principal is regional
+                            Service: Array.from(new Set(args.ServicePrincipals)).map((s) =>
+                                s === 'logs'
+                                    ? pulumi.interpolate`logs.${region.name}.amazonaws.com`
+                                    : `${s}.amazonaws.com`
+                            ),
+                        },
+                        Action: [
+                            'kms:Decrypt*',
+                            'kms:Describe*',
+                            'kms:Encrypt*',
+                            'kms:GenerateDataKey*',
+                            'kms:ReEncrypt*',
+                        ],
+                        Resource: '*',
+                    },
+                    //TMPL {{- end }}
+                    //TMPL {{- if .KeyPolicy.Statement }}
+                    //TMPL ...{{ .KeyPolicy.Statement }},
+                    //TMPL {{- end }}
+                ],
+            }),
+            //TMPL {{- if .Tags }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        },
+        { protect: args.protect }
+    )
+}
+
+function properties(object: aws.kms.Key, args: Args) {
+    return {
+        Arn: object.arn,
+        KeyId: object.keyId,
+    }
+}
@@ -0,0 +1,35 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    Function: aws.lambda.Function
+    FunctionVersion: pulumi.Input<string>
+    Description: string
+    RoutingConfig: ModelCaseWrapper<Record<string, any>>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.lambda.Alias {
+    return new aws.lambda.Alias(args.Name, {
+        name: args.Name,
+        functionName: args.Function.name,
+        functionVersion: args.FunctionVersion,
+        //TMPL {{- if .Description }}
+        description: args.Description,
+        //TMPL {{- end }}
+        //TMPL {{- if .RoutingConfig }}
+        routingConfig: {
+            //TMPL additionalVersionWeights: {{ .RoutingConfig.AdditionalVersionWeights }},
+        },
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.lambda.Alias, args: Args) {
+    return {
+        Arn: object.arn,
+        LambdaIntegrationUri: object.invokeArn,
+    }
+}
@@ -4,13 +4,15 @@ import { ModelCaseWrapper } from '../../wrappers'
 
 interface Args {
     Name: string
-    EventSource: aws.sqs.Queue
+    EventSourceArn: pulumi.Output<string>
     Function: aws.lambda.Function
+    Alias: aws.lambda.Alias
     FilterCriteria?: ModelCaseWrapper<Record<string, string>[]>
     BatchSize?: number
     Enabled?: boolean
     FunctionResponseTypes?: string[]
     MaximumBatchingWindowInSeconds?: number
+    StartingPosition?: string
     ScalingConfig?: object
     dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
 }
@@ -20,8 +22,12 @@ function create(args: Args): aws.lambda.EventSourceMapping {
     return new aws.lambda.EventSourceMapping(
         args.Name,
         {
-            eventSourceArn: args.EventSource.arn,
+            eventSourceArn: args.EventSourceArn,
+            //TMPL {{- if .Alias }}
+            functionName: args.Alias.arn,
+            //TMPL {{- else }}
             functionName: args.Function.name,
+            //TMPL {{- end }}
             //TMPL {{- if .FilterCriteria }}
             filterCriteria: {
                 filters: args.FilterCriteria,
@@ -39,6 +45,9 @@ function create(args: Args): aws.lambda.EventSourceMapping {
             //TMPL {{- if .MaximumBatchingWindowInSeconds }}
             maximumBatchingWindowInSeconds: args.MaximumBatchingWindowInSeconds,
             //TMPL {{- end }}
+            //TMPL {{- if .StartingPosition }}
+            startingPosition: args.StartingPosition,
+            //TMPL {{- end }}
             //TMPL {{- if .ScalingConfig }}
             scalingConfig: args.ScalingConfig,
             //TMPL {{- end }}
@@ -5,13 +5,20 @@ import { ModelCaseWrapper } from '../../wrappers'
 
 interface Args {
     Name: string
+    PackageType: string
     Image: docker.Image
+    Code: string
+    Handler: string
+    Runtime: string
+    Layers: aws.lambda.LayerVersion[]
     ExecutionRole: aws.iam.Role
     EnvironmentVariables: ModelCaseWrapper<Record<string, pulumi.Output<string>>>
     Subnets: aws.ec2.Subnet[]
     SecurityGroups: aws.ec2.SecurityGroup[]
     MemorySize: pulumi.Input<number>
     Timeout: pulumi.Input<number>
+    ReservedConcurrentExecutions: number
+    Publish: boolean
     EfsAccessPoint: aws.efs.AccessPoint
     dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
 }
@@ -21,14 +28,30 @@ function create(args: Args): aws.lambda.Function {
     return new aws.lambda.Function(
         args.Name,
         {
+            //TMPL {{- if and .PackageType (eq .PackageType "Zip") }}
+            packageType: 'Zip',
+            code: new pulumi.asset.FileArchive(args.Code),
+            handler: args.Handler,
+            runtime: args.Runtime,
+            //TMPL {{- else }}
             packageType: 'Image',
             imageUri: args.Image.imageName,
+            //TMPL {{- end }}
+            //TMPL {{- if .Layers }}
+            layers: args.Layers.map((layer) => layer.arn),
+            //TMPL {{- end }}
             //TMPL {{- if .MemorySize }}
             memorySize: args.MemorySize,
             //TMPL {{- end }}
             //TMPL {{- if .Timeout }}
             timeout: args.Timeout,
             //TMPL {{- end }}
+            //TMPL {{- if ne .ReservedConcurrentExecutions nil }}
+            reservedConcurrentExecutions: args.ReservedConcurrentExecutions,
+            //TMPL {{- end }}
+            //TMPL {{- if .Publish }}
+            publish: args.Publish,
+            //TMPL {{- end }}
             role: args.ExecutionRole.arn,
             name: args.Name,
             //TMPL {{- if .EfsAccessPoint }}
@@ -61,6 +84,9 @@ function create(args: Args): aws.lambda.Function {
 
 function properties(object: aws.lambda.Function, args: Args) {
     return {
+        Arn: object.arn,
         LambdaIntegrationUri: object.invokeArn,
+        FunctionName: object.name,
+        Version: object.version,
     }
 }
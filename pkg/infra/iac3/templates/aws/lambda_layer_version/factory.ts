@@ -0,0 +1,37 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Code: string
+    CompatibleRuntimes: string[]
+    CompatibleArchitectures: string[]
+    Description: string
+    LicenseInfo: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.lambda.LayerVersion {
+    return new aws.lambda.LayerVersion(args.Name, {
+        layerName: args.Name,
+        code: new pulumi.asset.FileArchive(args.Code),
+        //TMPL {{- if .CompatibleRuntimes }}
+        compatibleRuntimes: args.CompatibleRuntimes,
+        //TMPL {{- end }}
+        //TMPL {{- if .CompatibleArchitectures }}
+        compatibleArchitectures: args.CompatibleArchitectures,
+        //TMPL {{- end }}
+        //TMPL {{- if .Description }}
+        description: args.Description,
+        //TMPL {{- end }}
+        //TMPL {{- if .LicenseInfo }}
+        licenseInfo: args.LicenseInfo,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.lambda.LayerVersion, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
@@ -3,6 +3,7 @@ import * as pulumi from '@pulumi/pulumi'
 interface Args {
     Name: string
     Function: aws.lambda.Function
+    Alias: aws.lambda.Alias
     Principal: string
     Source: pulumi.Output<string>
     Action: string
@@ -13,6 +14,9 @@ function create(args: Args): aws.lambda.Permission {
     return new aws.lambda.Permission(args.Name, {
         action: args.Action,
         function: args.Function.name,
+        //TMPL {{- if .Alias }}
+        qualifier: args.Alias.name,
+        //TMPL {{- end }}
         principal: args.Principal,
         sourceArn: args.Source,
     })
@@ -0,0 +1,16 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Alias: aws.lambda.Alias
+    ProvisionedConcurrentExecutions: number
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.lambda.ProvisionedConcurrencyConfig {
+    return new aws.lambda.ProvisionedConcurrencyConfig(args.Name, {
+        functionName: args.Alias.functionName,
+        qualifier: args.Alias.name,
+        provisionedConcurrentExecutions: args.ProvisionedConcurrentExecutions,
+    })
+}
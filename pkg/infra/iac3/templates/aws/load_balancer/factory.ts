@@ -33,6 +33,11 @@ function create(args: Args): aws.lb.LoadBalancer {
 function properties(object: aws.lb.LoadBalancer, args: Args) {
     return {
         NlbUri: pulumi.interpolate`http://${object.dnsName}`,
+        DnsName: object.dnsName,
+        ZoneId: object.zoneId,
+        OAuthCallbackUrl: pulumi.interpolate`https://${object.dnsName}/oauth2/idpresponse`,
+        Arn: object.arn,
+        ArnSuffix: object.arnSuffix,
     }
 }
 
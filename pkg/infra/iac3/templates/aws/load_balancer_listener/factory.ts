@@ -6,6 +6,8 @@ interface Args {
     Port: number
     Protocol: string
     LoadBalancer: aws.lb.LoadBalancer
+    Certificate?: aws.acm.Certificate
+    CertificateValidation?: aws.acm.CertificateValidation
     DefaultActions: TemplateWrapper<aws.types.input.lb.ListenerDefaultAction[]>
 }
 
@@ -16,5 +18,16 @@ function create(args: Args): aws.lb.Listener {
         defaultActions: args.DefaultActions,
         port: args.Port,
         protocol: args.Protocol,
+        //TMPL {{- if .CertificateValidation }}
+        certificateArn: args.CertificateValidation.certificateArn,
+        //TMPL {{- else if .Certificate }}
+        certificateArn: args.Certificate.arn,
+        //TMPL {{- end }}
     })
 }
+
+function properties(object: aws.lb.Listener, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
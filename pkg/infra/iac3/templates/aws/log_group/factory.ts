@@ -2,15 +2,21 @@ import * as aws from '@pulumi/aws'
 
 interface Args {
     Name: string
-    LogGroupName: string
+    LogGroupName?: string
     RetentionInDays: number
+    KmsKey?: aws.kms.Key
 }
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): aws.cloudwatch.LogGroup {
     return new aws.cloudwatch.LogGroup(args.Name, {
+        //TMPL {{- if .LogGroupName }}
         name: args.LogGroupName,
+        //TMPL {{- end }}
         retentionInDays: args.RetentionInDays,
+        //TMPL {{- if .KmsKey }}
+        kmsKeyId: args.KmsKey.arn,
+        //TMPL {{- end }}
     })
 }
 
@@ -0,0 +1,26 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    LogGroup: aws.cloudwatch.LogGroup
+    FilterPattern: string
+    MetricTransformation: ModelCaseWrapper<Record<string, any>>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.cloudwatch.LogMetricFilter {
+    return new aws.cloudwatch.LogMetricFilter(args.Name, {
+        name: args.Name,
+        logGroupName: args.LogGroup.name,
+        pattern: args.FilterPattern,
+        metricTransformation: {
+            //TMPL name: {{ .MetricTransformation.MetricName }},
+            //TMPL namespace: {{ .MetricTransformation.MetricNamespace }},
+            //TMPL value: {{ .MetricTransformation.MetricValue }},
+            //TMPL {{- if .MetricTransformation.DefaultValue }}
+            //TMPL defaultValue: `${ {{ .MetricTransformation.DefaultValue }} }`,
+            //TMPL {{- end }}
+        },
+    })
+}
@@ -0,0 +1,96 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    EngineVersion: string
+    InstanceType: string
+    InstanceCount: number
+    DedicatedMasterEnabled: boolean
+    DedicatedMasterType?: string
+    DedicatedMasterCount?: number
+    ZoneAwarenessEnabled: boolean
+    AvailabilityZoneCount: number
+    EbsEnabled: boolean
+    VolumeType: string
+    VolumeSize: number
+    Iops?: number
+    EncryptAtRest: boolean
+    KmsKey?: aws.kms.Key
+    NodeToNodeEncryption: boolean
+    EnforceHttps: boolean
+    TlsSecurityPolicy: string
+    Subnets: aws.ec2.Subnet[]
+    SecurityGroups: aws.ec2.SecurityGroup[]
+    AccessPolicies?: ModelCaseWrapper<aws.iam.PolicyDocument>
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.opensearch.Domain {
+    return new aws.opensearch.Domain(args.Name, {
+        domainName: args.Name,
+        engineVersion: args.EngineVersion,
+        clusterConfig: {
+            instanceType: args.InstanceType,
+            instanceCount: args.InstanceCount,
+            dedicatedMasterEnabled: args.DedicatedMasterEnabled,
+            //TMPL {{- if .DedicatedMasterEnabled }}
+            dedicatedMasterType: args.DedicatedMasterType,
+            dedicatedMasterCount: args.DedicatedMasterCount,
+            //TMPL {{- end }}
+            zoneAwarenessEnabled: args.ZoneAwarenessEnabled,
+            //TMPL {{- if .ZoneAwarenessEnabled }}
+            zoneAwarenessConfig: {
+                availabilityZoneCount: args.AvailabilityZoneCount,
+            },
+            //TMPL {{- end }}
+        },
+        ebsOptions: {
+            ebsEnabled: args.EbsEnabled,
+            //TMPL {{- if .EbsEnabled }}
+            volumeType: args.VolumeType,
+            volumeSize: args.VolumeSize,
+            //TMPL {{- if .Iops }}
+            iops: args.Iops,
+            //TMPL {{- end }}
+            //TMPL {{- end }}
+        },
+        encryptAtRest: {
+            enabled: args.EncryptAtRest,
+            //TMPL {{- if .KmsKey }}
+            kmsKeyId: args.KmsKey.arn,
+            //TMPL {{- end }}
+        },
+        nodeToNodeEncryption: {
+            enabled: args.NodeToNodeEncryption,
+        },
+        domainEndpointOptions: {
+            enforceHttps: args.EnforceHttps,
+            tlsSecurityPolicy: args.TlsSecurityPolicy,
+        },
+        vpcOptions: {
+            //TMPL {{- if .ZoneAwarenessEnabled }}
+            subnetIds: args.Subnets.map((subnet) => subnet.id),
+            //TMPL {{- else }}
+            subnetIds: args.Subnets.slice(0, 1).map((subnet) => subnet.id),
+            //TMPL {{- end }}
+            securityGroupIds: args.SecurityGroups.map((sg) => sg.id),
+        },
+        //TMPL {{- if .AccessPolicies }}
+        accessPolicies: pulumi.jsonStringify(args.AccessPolicies),
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.opensearch.Domain, args: Args) {
+    return {
+        Arn: object.arn,
+        AllIndexPaths: pulumi.interpolate`${object.arn}/*`,
+        Endpoint: object.endpoint,
+    }
+}
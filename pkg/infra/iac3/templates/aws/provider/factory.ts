@@ -0,0 +1,18 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Region: pulumi.Output<pulumi.UnwrappedObject<aws.GetRegionResult>>
+    AssumeRole?: aws.types.input.ProviderAssumeRole
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.Provider {
+    return new aws.Provider(args.Name, {
+        region: args.Region.name as pulumi.Output<aws.Region>,
+        //TMPL {{- if .AssumeRole }}
+        assumeRole: args.AssumeRole,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,81 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as aws from '@pulumi/aws'
+import { accountId, region, kloConfig } from '../../globals'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    SubnetGroup: aws.rds.SubnetGroup
+    SecurityGroups: aws.ec2.SecurityGroup[]
+    IamDatabaseAuthenticationEnabled: boolean
+    DatabaseName: string
+    Engine: string
+    EngineVersion: string
+    SkipFinalSnapshot: boolean
+    BackupRetentionPeriod: number
+    ServerlessV2ScalingConfiguration?: Record<string, number>
+    KmsKey?: aws.kms.Key
+    Tags?: ModelCaseWrapper<Record<string, string>>
+    Username: string
+    Password: string
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.rds.Cluster {
+    return new aws.rds.Cluster(
+        args.Name,
+        {
+            clusterIdentifier: args.Name,
+            engine: args.Engine,
+            engineMode: 'provisioned',
+            engineVersion: args.EngineVersion,
+            databaseName: args.DatabaseName,
+            masterUsername: kloConfig.requireSecret(`${args.Name}-username`),
+            masterPassword: kloConfig.requireSecret(`${args.Name}-password`),
+            iamDatabaseAuthenticationEnabled: args.IamDatabaseAuthenticationEnabled,
+            dbSubnetGroupName: args.SubnetGroup.name,
+            vpcSecurityGroupIds: args.SecurityGroups.map((sg) => sg.id),
+            skipFinalSnapshot: args.SkipFinalSnapshot,
+            backupRetentionPeriod: args.BackupRetentionPeriod,
+            //TMPL {{- if .ServerlessV2ScalingConfiguration }}
+            serverlessv2ScalingConfiguration: args.ServerlessV2ScalingConfiguration,
+            //TMPL {{- end }}
+            //TMPL {{- if .KmsKey }}
+            storageEncrypted: true,
+            kmsKeyId: args.KmsKey.arn,
+            //TMPL {{- end }}
+            //TMPL {{- if .Tags }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        },
+        { protect: args.protect }
+    )
+}
+
+function properties(object: aws.rds.Cluster, args: Args) {
+    return {
+        Password: kloConfig.requireSecret(`${args.Name}-password`),
+        Username: kloConfig.requireSecret(`${args.Name}-username`),
+        CredentialsSecretValue: pulumi.jsonStringify({
+            username: object.masterUsername,
+            password: object.masterPassword,
+        }),
+        RdsConnectionArn: pulumi.interpolate`arn:aws:rds-db:${region.name}:${accountId.accountId}:dbuser:${object.clusterResourceId}/${object.masterUsername}`,
+        Endpoint: pulumi.interpolate`${object.endpoint}:${object.port}`,
+        ReaderEndpoint: pulumi.interpolate`${object.readerEndpoint}:${object.port}`,
+        Identifier: object.clusterIdentifier,
+        Arn: object.arn,
+    }
+}
+
+function infraExports(
+    object: ReturnType<typeof create>,
+    args: Args,
+    props: ReturnType<typeof properties>
+) {
+    return {
+        Endpoint: object.endpoint,
+        ReaderEndpoint: object.readerEndpoint,
+    }
+}
@@ -0,0 +1,35 @@
+import * as pulumi from '@pulumi/pulumi'
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    Cluster: aws.rds.Cluster
+    InstanceClass: string
+    PromotionTier?: number
+    Tags?: ModelCaseWrapper<Record<string, string>>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.rds.ClusterInstance {
+    return new aws.rds.ClusterInstance(args.Name, {
+        identifier: args.Name,
+        clusterIdentifier: args.Cluster.id,
+        instanceClass: args.InstanceClass,
+        engine: args.Cluster.engine as pulumi.Output<aws.rds.EngineType>,
+        engineVersion: args.Cluster.engineVersion,
+        dbSubnetGroupName: args.Cluster.dbSubnetGroupName,
+        //TMPL {{- if .PromotionTier }}
+        promotionTier: args.PromotionTier,
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.rds.ClusterInstance, args: Args) {
+    return {
+        Endpoint: pulumi.interpolate`${object.endpoint}:${object.port}`,
+    }
+}
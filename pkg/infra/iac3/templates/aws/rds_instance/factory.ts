@@ -13,6 +13,7 @@ interface Args {
     InstanceClass: string
     SkipFinalSnapshot: boolean
     AllocatedStorage: number
+    KmsKey?: aws.kms.Key
     Username: string
     Password: string
     protect: boolean
@@ -34,6 +35,10 @@ function create(args: Args): aws.rds.Instance {
             vpcSecurityGroupIds: args.SecurityGroups.map((sg) => sg.id),
             skipFinalSnapshot: args.SkipFinalSnapshot,
             allocatedStorage: args.AllocatedStorage,
+            //TMPL {{- if .KmsKey }}
+            storageEncrypted: true,
+            kmsKeyId: args.KmsKey.arn,
+            //TMPL {{- end }}
         },
         { protect: args.protect }
     )
@@ -46,9 +51,15 @@ function properties(object: aws.rds.Instance, args: Args) {
         CredentialsSecretValue: pulumi.jsonStringify({
             username: object.username,
             password: object.password,
+            engine: object.engine,
+            host: object.address,
+            port: object.port,
+            dbname: object.dbName,
         }),
         RdsConnectionArn: pulumi.interpolate`arn:aws:rds-db:${region.name}:${accountId.accountId}:dbuser:${object.resourceId}/${object.username}`,
+        Address: object.address,
         Endpoint: object.endpoint,
+        Identifier: object.identifier,
     }
 }
 
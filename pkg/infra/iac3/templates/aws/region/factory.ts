@@ -1,11 +1,19 @@
 import * as aws from '@pulumi/aws'
 import * as pulumi from '@pulumi/pulumi'
 
-interface Args {}
+interface Args {
+    RegionName?: string
+}
 
 // noinspection JSUnusedLocalSymbols
 function create(args: Args): pulumi.Output<pulumi.UnwrappedObject<aws.GetRegionResult>> {
-    return pulumi.output(aws.getRegion({}))
+    return pulumi.output(
+        aws.getRegion({
+            //TMPL {{- if .RegionName }}
+            name: args.RegionName,
+            //TMPL {{- end }}
+        })
+    )
 }
 
 function properties(
@@ -0,0 +1,48 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    DomainName: string
+    Comment: string
+    ForceDestroy: boolean
+    Tags: ModelCaseWrapper<Record<string, string>>
+    ZoneId?: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.route53.Zone {
+    return new aws.route53.Zone(args.Name, {
+        name: args.DomainName,
+        //TMPL {{- if .Comment }}
+        comment: args.Comment,
+        //TMPL {{- end }}
+        //TMPL {{- if .ForceDestroy }}
+        forceDestroy: args.ForceDestroy,
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.route53.Zone, args: Args) {
+    return {
+        ZoneId: object.zoneId,
+        NameServers: object.nameServers,
+    }
+}
+
+function importResource(args: Args): aws.route53.Zone {
+    return aws.route53.Zone.get(args.Name, args.ZoneId)
+}
+
+function infraExports(
+    object: ReturnType<typeof create>,
+    args: Args,
+    props: ReturnType<typeof properties>
+) {
+    return {
+        NameServers: object.nameServers,
+    }
+}
@@ -0,0 +1,43 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    HostedZone: aws.route53.Zone
+    RecordName: pulumi.Input<string>
+    Type: string
+    Ttl: number
+    Records: pulumi.Input<string>[]
+    AliasTarget: ModelCaseWrapper<Record<string, any>>
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.route53.Record {
+    return new aws.route53.Record(
+        args.Name,
+        {
+            zoneId: args.HostedZone.zoneId,
+            name: args.RecordName,
+            type: args.Type,
+            //TMPL {{- if .AliasTarget }}
+            //TMPL aliases: [
+            //TMPL     {
+            //TMPL         name: {{ .AliasTarget.DnsName }},
+            //TMPL         zoneId: {{ .AliasTarget.HostedZoneId }},
+            //TMPL         evaluateTargetHealth: {{ if .AliasTarget.EvaluateTargetHealth }}{{ .AliasTarget.EvaluateTargetHealth }}{{ else }}false{{ end }},
+            //TMPL     },
+            //TMPL ],
+            //TMPL {{- else }}
+            //TMPL {{- if .Ttl }}
+            ttl: args.Ttl,
+            //TMPL {{- end }}
+            records: args.Records,
+            //TMPL {{- end }}
+        },
+        {
+            dependsOn: args.dependsOn,
+        }
+    )
+}
@@ -6,6 +6,7 @@ interface Args {
     ForceDestroy: boolean
     IndexDocument: string
     SSEAlgorithm: string
+    KmsKey?: aws.kms.Key
     protect: boolean
 }
 
@@ -20,6 +21,9 @@ function create(args: Args): aws.s3.Bucket {
                 rule: {
                     applyServerSideEncryptionByDefault: {
                         sseAlgorithm: args.SSEAlgorithm,
+                        //TMPL {{- if .KmsKey }}
+                        kmsMasterKeyId: args.KmsKey.arn,
+                        //TMPL {{- end }}
                     },
                     bucketKeyEnabled: true,
                 },
@@ -0,0 +1,26 @@
+import * as aws from '@pulumi/aws'
+import * as awsInputs from '@pulumi/aws/types/input'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Bucket: aws.s3.Bucket
+    LambdaFunctions: awsInputs.s3.BucketNotificationLambdaFunction[]
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.s3.BucketNotification {
+    return new aws.s3.BucketNotification(
+        args.Name,
+        {
+            bucket: args.Bucket.id,
+            //TMPL {{- if .LambdaFunctions }}
+            lambdaFunctions: args.LambdaFunctions,
+            //TMPL {{- end }}
+        },
+        {
+            dependsOn: args.dependsOn,
+        }
+    )
+}
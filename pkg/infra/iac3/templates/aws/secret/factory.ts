@@ -2,6 +2,9 @@ import * as aws from '@pulumi/aws'
 
 interface Args {
     Name: string
+    KmsKey?: aws.kms.Key
+    ReplicaRegions?: aws.types.input.secretsmanager.SecretReplica[]
+    ForceOverwriteReplicaSecret?: boolean
     protect: boolean
 }
 
@@ -12,6 +15,15 @@ function create(args: Args): aws.secretsmanager.Secret {
         {
             name: args.Name,
             recoveryWindowInDays: 0,
+            //TMPL {{- if .KmsKey }}
+            kmsKeyId: args.KmsKey.arn,
+            //TMPL {{- end }}
+            //TMPL {{- if .ReplicaRegions }}
+            replicas: args.ReplicaRegions,
+            //TMPL {{- end }}
+            //TMPL {{- if .ForceOverwriteReplicaSecret }}
+            forceOverwriteReplicaSecret: args.ForceOverwriteReplicaSecret,
+            //TMPL {{- end }}
         },
         { protect: args.protect }
     )
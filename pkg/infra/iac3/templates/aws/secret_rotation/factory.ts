@@ -0,0 +1,71 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+import { region } from '../../globals'
+
+interface Args {
+    Name: string
+    Secret: aws.secretsmanager.Secret
+    RotationLambda?: aws.lambda.Function
+    RotationTemplate?: string
+    Subnets?: aws.ec2.Subnet[]
+    SecurityGroups?: aws.ec2.SecurityGroup[]
+    KmsKey?: aws.kms.Key
+    AutomaticallyAfterDays: number
+    ScheduleExpression?: string
+    Duration?: string
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.secretsmanager.SecretRotation {
+    return (() => {
+        //TMPL {{- if and .RotationTemplate (not .RotationLambda) }}
+        const rotationApp = new aws.serverlessrepository.CloudFormationStack(
+            `${args.Name}-rotation`,
+            {
+                applicationId: `arn:aws:serverlessrepo:us-east-1:297356227824:applications/${args.RotationTemplate}`,
+                capabilities: ['CAPABILITY_IAM', 'CAPABILITY_RESOURCE_POLICY'],
+                parameters: {
+                    functionName: `${args.Name}-rotation`,
+                    endpoint: pulumi.interpolate`https://secretsmanager.${region.name}.amazonaws.com`,
+                    //TMPL {{- if .Subnets }}
+                    vpcSubnetIds: pulumi
+                        .all(args.Subnets.map((subnet) => subnet.id))
+                        .apply((ids) => ids.join(',')),
+                    //TMPL {{- end }}
+                    //TMPL {{- if .SecurityGroups }}
+                    vpcSecurityGroupIds: pulumi
+                        .all(args.SecurityGroups.map((sg) => sg.id))
+                        .apply((ids) => ids.join(',')),
+                    //TMPL {{- end }}
+                    //TMPL {{- if .KmsKey }}
+                    kmsKeyArn: args.KmsKey.arn,
+                    //TMPL {{- end }}
+                },
+            }
+        )
+        //TMPL {{- end }}
+        return new aws.secretsmanager.SecretRotation(
+            args.Name,
+            {
+                secretId: args.Secret.id,
+                //TMPL {{- if .RotationLambda }}
+                rotationLambdaArn: args.RotationLambda.arn,
+                //TMPL {{- else }}
+                rotationLambdaArn: rotationApp.outputs['RotationLambdaARN'],
+                //TMPL {{- end }}
+                rotationRules: {
+                    //TMPL {{- if .ScheduleExpression }}
+                    scheduleExpression: args.ScheduleExpression,
+                    //TMPL {{- else }}
+                    automaticallyAfterDays: args.AutomaticallyAfterDays,
+                    //TMPL {{- end }}
+                    //TMPL {{- if .Duration }}
+                    duration: args.Duration,
+                    //TMPL {{- end }}
+                },
+            },
+            { dependsOn: args.dependsOn }
+        )
+    })()
+}
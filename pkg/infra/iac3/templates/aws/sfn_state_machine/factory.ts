@@ -0,0 +1,47 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    Definition: ModelCaseWrapper<any>
+    Role: aws.iam.Role
+    StateMachineType: string
+    Tags?: ModelCaseWrapper<Record<string, string>>
+    protect: boolean
+    dependsOn?: pulumi.Input<pulumi.Input<pulumi.Resource>[]> | pulumi.Input<pulumi.Resource>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.sfn.StateMachine {
+    return new aws.sfn.StateMachine(
+        args.Name,
+        {
+            name: args.Name,
+            definition: pulumi
+                .output(args.Definition)
+                .apply((d) => (typeof d === 'string' ? d : JSON.stringify(d))),
+            roleArn: args.Role.arn,
+            type: args.StateMachineType,
+            //TMPL {{- if .Tags }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        },
+        {
+            dependsOn: args.dependsOn,
+            //TMPL {{- if .protect }}
+            protect: args.protect,
+            //TMPL {{- end }}
+        }
+    )
+}
+
+function properties(object: aws.sfn.StateMachine, args: Args) {
+    return {
+        Arn: object.arn,
+        ApiStartExecutionUri: object.arn.apply(
+            (arn) => `arn:aws:apigateway:${arn.split(':')[3]}:states:action/StartExecution`
+        ),
+        ApiStartExecutionTemplate: pulumi.interpolate`{"input": "$util.escapeJavaScript($input.json('$'))", "stateMachineArn": "${object.arn}"}`,
+    }
+}
@@ -0,0 +1,26 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    Topic: aws.sns.Topic
+    Protocol: string
+    Endpoint: pulumi.Output<string>
+    RawMessageDelivery?: boolean
+    FilterPolicy?: string
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.sns.TopicSubscription {
+    return new aws.sns.TopicSubscription(args.Name, {
+        topic: args.Topic.arn,
+        protocol: args.Protocol,
+        endpoint: args.Endpoint,
+        //TMPL {{- if .RawMessageDelivery }}
+        rawMessageDelivery: args.RawMessageDelivery,
+        //TMPL {{- end }}
+        //TMPL {{- if .FilterPolicy }}
+        filterPolicy: args.FilterPolicy,
+        //TMPL {{- end }}
+    })
+}
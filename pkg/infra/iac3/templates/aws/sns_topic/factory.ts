@@ -0,0 +1,43 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    FifoTopic?: boolean
+    ContentBasedDeduplication?: boolean
+    DisplayName?: string
+    Tags?: ModelCaseWrapper<Record<string, string>>
+    protect: boolean
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.sns.Topic {
+    return new aws.sns.Topic(
+        args.Name,
+        {
+            //TMPL {{- if .FifoTopic }}
+            // FIFO topic names must end in .fifo
+            name: `${args.Name}.fifo`,
+            fifoTopic: args.FifoTopic,
+            //TMPL {{- end }}
+            //TMPL {{- if .ContentBasedDeduplication }}
+            contentBasedDeduplication: args.ContentBasedDeduplication,
+            //TMPL {{- end }}
+            //TMPL {{- if .DisplayName }}
+            displayName: args.DisplayName,
+            //TMPL {{- end }}
+            //TMPL {{- if .Tags }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        },
+        //TMPL {{- if .protect }}
+        { protect: args.protect }
+        //TMPL {{- end }}
+    )
+}
+
+function properties(object: aws.sns.Topic, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
@@ -0,0 +1,16 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    Queue: aws.sqs.Queue
+    Policy: ModelCaseWrapper<aws.iam.PolicyDocument>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.sqs.QueuePolicy {
+    return new aws.sqs.QueuePolicy(args.Name, {
+        queueUrl: args.Queue.url,
+        policy: args.Policy,
+    })
+}
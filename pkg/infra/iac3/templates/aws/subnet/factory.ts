@@ -7,6 +7,7 @@ interface Args {
     Vpc: aws.ec2.Vpc
     AvailabilityZone: pulumi.Output<string>
     MapPublicIpOnLaunch: boolean
+    Id?: string
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -21,3 +22,13 @@ function create(args: Args): aws.ec2.Subnet {
         },
     })
 }
+
+function properties(object: aws.ec2.Subnet, args: Args) {
+    return {
+        Id: object.id,
+    }
+}
+
+function importResource(args: Args): aws.ec2.Subnet {
+    return aws.ec2.Subnet.get(args.Name, args.Id)
+}
@@ -1,5 +1,5 @@
 import * as aws from '@pulumi/aws'
-import { TemplateWrapper } from '../../wrappers'
+import { ModelCaseWrapper, TemplateWrapper } from '../../wrappers'
 
 interface Args {
     Name: string
@@ -7,7 +7,7 @@ interface Args {
     Protocol: string
     Vpc: aws.ec2.Vpc
     TargetType: string
-    Tags: Record<string, string>
+    Tags: ModelCaseWrapper<Record<string, string>>
     Targets: { Id: string; Port: number }[]
     HealthCheck: TemplateWrapper<Record<string, any>>
     LambdaMultiValueHeadersEnabled?: boolean
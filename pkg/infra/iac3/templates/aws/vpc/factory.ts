@@ -5,7 +5,7 @@ interface Args {
     CidrBlock: string
     EnableDnsHostnames: boolean
     EnableDnsSupport: boolean
-    Arn?: string
+    Id?: string
 }
 
 // noinspection JSUnusedLocalSymbols
@@ -28,5 +28,5 @@ function properties(object: aws.ec2.Vpc, args: Args) {
 }
 
 function importResource(args: Args): aws.ec2.Vpc {
-    return aws.ec2.Vpc.get(args.Name, args.Arn)
+    return aws.ec2.Vpc.get(args.Name, args.Id)
 }
@@ -8,7 +8,7 @@ interface Args {
     ServiceName: string
     VpcEndpointType: string
     Subnets: aws.ec2.Subnet[]
-    SecurityGroupIds: pulumi.Input<string[]> | undefined
+    SecurityGroups: aws.ec2.SecurityGroup[]
     RouteTables: aws.ec2.RouteTable[]
 }
 
@@ -21,7 +21,9 @@ function create(args: Args): aws.ec2.VpcEndpoint {
         //TMPL {{- if eq .VpcEndpointType "Interface"}}
         privateDnsEnabled: true,
         subnetIds: args.Subnets.map((x) => x.id),
-        securityGroupIds: args.SecurityGroupIds,
+        //TMPL {{- if .SecurityGroups }}
+        securityGroupIds: args.SecurityGroups.map((sg) => sg.id),
+        //TMPL {{- end }}
         //TMPL {{- end }}
         //TMPL {{- if and .RouteTables (eq .VpcEndpointType "Gateway")}}
         routeTableIds: args.RouteTables.map((rt) => rt.id),
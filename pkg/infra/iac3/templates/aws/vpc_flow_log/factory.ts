@@ -0,0 +1,36 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Vpc: aws.ec2.Vpc
+    TrafficType: string
+    LogDestinationType: string
+    LogGroup?: aws.cloudwatch.LogGroup
+    S3Bucket?: aws.s3.Bucket
+    IamRole?: aws.iam.Role
+    MaxAggregationInterval: number
+    LogFormat?: string
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.ec2.FlowLog {
+    return new aws.ec2.FlowLog(args.Name, {
+        vpcId: args.Vpc.id,
+        trafficType: args.TrafficType,
+        logDestinationType: args.LogDestinationType,
+        //TMPL {{- if eq .LogDestinationType "s3" }}
+        logDestination: args.S3Bucket.arn,
+        //TMPL {{- else }}
+        logDestination: args.LogGroup.arn,
+        iamRoleArn: args.IamRole.arn,
+        //TMPL {{- end }}
+        maxAggregationInterval: args.MaxAggregationInterval,
+        //TMPL {{- if .LogFormat }}
+        logFormat: args.LogFormat,
+        //TMPL {{- end }}
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
@@ -0,0 +1,35 @@
+import * as aws from '@pulumi/aws'
+
+interface Args {
+    Name: string
+    Vpc: aws.ec2.Vpc
+    PeerVpc: aws.ec2.Vpc
+    PeerOwnerId?: string
+    PeerRegion?: string
+    AutoAccept: boolean
+    Tags: Record<string, string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.ec2.VpcPeeringConnection {
+    return new aws.ec2.VpcPeeringConnection(args.Name, {
+        vpcId: args.Vpc.id,
+        peerVpcId: args.PeerVpc.id,
+        //TMPL {{- if .PeerOwnerId }}
+        peerOwnerId: args.PeerOwnerId,
+        //TMPL {{- end }}
+        //TMPL {{- if .PeerRegion }}
+        peerRegion: args.PeerRegion,
+        //TMPL {{- end }}
+        autoAccept: args.AutoAccept,
+        //TMPL {{- if .Tags }}
+        tags: args.Tags,
+        //TMPL {{- end }}
+    })
+}
+
+function properties(object: aws.ec2.VpcPeeringConnection, args: Args) {
+    return {
+        Id: object.id,
+    }
+}
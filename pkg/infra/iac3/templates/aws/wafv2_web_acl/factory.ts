@@ -0,0 +1,92 @@
+import * as aws from '@pulumi/aws'
+import { ModelCaseWrapper } from '../../wrappers'
+
+interface Args {
+    Name: string
+    Scope: string
+    DefaultAction: string
+    ManagedRuleGroups: {
+        name: string
+        vendorName: string
+        excludedRules?: string[]
+        overrideAction: string
+    }[]
+    RateBasedRules: {
+        name: string
+        limit: number
+        action: string
+    }[]
+    CloudWatchMetricsEnabled: boolean
+    SampledRequestsEnabled: boolean
+    Tags: ModelCaseWrapper<Record<string, string>>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.wafv2.WebAcl {
+    return (() => {
+        const visibilityConfig = (metricName: string) => ({
+            cloudwatchMetricsEnabled: args.CloudWatchMetricsEnabled,
+            sampledRequestsEnabled: args.SampledRequestsEnabled,
+            metricName: metricName,
+        })
+        const rules: Omit<aws.types.input.wafv2.WebAclRule, 'priority'>[] = [
+            //TMPL {{- if .ManagedRuleGroups }}
+            ...args.ManagedRuleGroups.map(
+                (group: {
+                    name: string
+                    vendorName: string
+                    excludedRules?: string[]
+                    overrideAction: string
+                }) => ({
+                    name: group.name,
+                    overrideAction: group.overrideAction === 'count' ? { count: {} } : { none: {} },
+                    statement: {
+                        managedRuleGroupStatement: {
+                            name: group.name,
+                            vendorName: group.vendorName,
+                            ruleActionOverrides: (group.excludedRules ?? []).map((rule) => ({
+                                name: rule,
+                                actionToUse: { count: {} },
+                            })),
+                        },
+                    },
+                    visibilityConfig: visibilityConfig(group.name),
+                })
+            ),
+            //TMPL {{- end }}
+            //TMPL {{- if .RateBasedRules }}
+            ...args.RateBasedRules.map((rule) => ({
+                name: rule.name,
+                action: rule.action === 'count' ? { count: {} } : { block: {} },
+                statement: {
+                    rateBasedStatement: {
+                        limit: rule.limit,
+                        aggregateKeyType: 'IP',
+                    },
+                },
+                visibilityConfig: visibilityConfig(rule.name),
+            })),
+            //TMPL {{- end }}
+        ]
+        return new aws.wafv2.WebAcl(args.Name, {
+            name: args.Name,
+            scope: args.Scope,
+            //TMPL {{- if eq .DefaultAction "block" }}
+            defaultAction: { block: {} },
+            //TMPL {{- else }}
+            defaultAction: { allow: {} },
+            //TMPL {{- end }}
+            rules: rules.map((rule, priority) => ({ ...rule, priority: priority })),
+            visibilityConfig: visibilityConfig(args.Name),
+            //TMPL {{- if .Tags }}
+            tags: args.Tags,
+            //TMPL {{- end }}
+        })
+    })()
+}
+
+function properties(object: aws.wafv2.WebAcl, args: Args) {
+    return {
+        Arn: object.arn,
+    }
+}
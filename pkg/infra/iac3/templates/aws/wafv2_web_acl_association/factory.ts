@@ -0,0 +1,16 @@
+import * as aws from '@pulumi/aws'
+import * as pulumi from '@pulumi/pulumi'
+
+interface Args {
+    Name: string
+    WebAcl: aws.wafv2.WebAcl
+    ResourceArn: pulumi.Input<string>
+}
+
+// noinspection JSUnusedLocalSymbols
+function create(args: Args): aws.wafv2.WebAclAssociation {
+    return new aws.wafv2.WebAclAssociation(args.Name, {
+        webAclArn: args.WebAcl.arn,
+        resourceArn: args.ResourceArn,
+    })
+}
@@ -1,6 +1,7 @@
 package iac3
 
 import (
+	"bufio"
 	"errors"
 	"fmt"
 	"io"
@@ -17,6 +18,16 @@ func (tc *TemplatesCompiler) RenderImports(out io.Writer) error {
 	}
 
 	allImports := make(map[string]struct{})
+	// globals.ts is inlined without its imports, so they must always be present
+	// even when no resource template happens to import the same modules.
+	globalImports, err := globalsImports()
+	if err != nil {
+		return err
+	}
+	for _, statement := range globalImports {
+		allImports[statement] = struct{}{}
+	}
+
 	var errs error
 	for _, r := range resources {
 		t, err := tc.ResourceTemplate(r)
@@ -50,3 +61,21 @@ func (tc *TemplatesCompiler) RenderImports(out io.Writer) error {
 
 	return nil
 }
+
+func globalsImports() ([]string, error) {
+	globalsFile, err := files.Open("templates/globals.ts")
+	if err != nil {
+		return nil, err
+	}
+	defer globalsFile.Close()
+
+	var imports []string
+	scan := bufio.NewScanner(globalsFile)
+	for scan.Scan() {
+		text := strings.TrimSpace(scan.Text())
+		if strings.HasPrefix(text, "import") {
+			imports = append(imports, text)
+		}
+	}
+	return imports, scan.Err()
+}
@@ -0,0 +1,282 @@
+package iac3
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	knowledgebase "github.com/klothoplatform/klotho/pkg/knowledge_base2"
+	"github.com/klothoplatform/klotho/pkg/set"
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+type (
+	// ReferenceError is a reference in a TypeScript program which will fail at runtime.
+	ReferenceError struct {
+		Name   string
+		Line   uint32
+		Column uint32
+		// UsedBeforeDeclared is true when the reference is declared, but only after it is used.
+		UsedBeforeDeclared bool
+	}
+)
+
+// builtinIdentifiers are the runtime globals the generated program may reference without declaring them.
+var builtinIdentifiers = set.SetOf(
+	"Array", "Boolean", "Buffer", "Date", "Error", "JSON", "Map", "Math", "Number", "Object", "Promise",
+	"RegExp", "Set", "String", "console", "encodeURIComponent", "parseInt", "process", "require", "undefined",
+	"__dirname",
+)
+
+func (e ReferenceError) Error() string {
+	if e.UsedBeforeDeclared {
+		return fmt.Sprintf("%d:%d: %s is used before it is declared", e.Line, e.Column, e.Name)
+	}
+	return fmt.Sprintf("%d:%d: %s is not declared", e.Line, e.Column, e.Name)
+}
+
+// VerifyProgram checks that every variable referenced by the generated program is declared before it is used.
+// Scoping is simplified: a binding anywhere within a top-level statement (function parameters, nested declarations)
+// counts for the whole statement.
+func VerifyProgram(content []byte) error {
+	root, err := parseFile(bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	if root.HasError() {
+		return fmt.Errorf("program has syntax errors: %s", root.String())
+	}
+
+	declared := make(set.Set[string])
+	declared.AddFrom(builtinIdentifiers)
+	// allDeclarations are the names declared anywhere in the program, to tell references to later
+	// declarations apart from references to names which are never declared.
+	allDeclarations := make(set.Set[string])
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		stmt := root.NamedChild(i)
+		allDeclarations.AddFrom(topLevelDeclarations(stmt))
+		// Function declarations are hoisted, so they're available from the start
+		if stmt.Type() == "function_declaration" {
+			declared.Add(stmt.ChildByFieldName("name").Content())
+		}
+	}
+
+	var errs error
+	for i := 0; i < int(root.NamedChildCount()); i++ {
+		stmt := root.NamedChild(i)
+		if stmt.Type() == "import_statement" {
+			declared.AddFrom(bindings(stmt))
+			continue
+		}
+
+		declares := topLevelDeclarations(stmt)
+		locals := bindings(stmt)
+		for name := range declares {
+			locals.Remove(name)
+		}
+		walkNodes(stmt, func(n *sitter.Node) {
+			if !isReference(n) {
+				return
+			}
+			name := n.Content()
+			if declared.Contains(name) || locals.Contains(name) {
+				return
+			}
+			pos := n.StartPoint()
+			errs = errors.Join(errs, ReferenceError{
+				Name:               name,
+				Line:               pos.Row + 1,
+				Column:             pos.Column + 1,
+				UsedBeforeDeclared: allDeclarations.Contains(name),
+			})
+		})
+		declared.AddFrom(declares)
+	}
+	return errs
+}
+
+// topLevelDeclarations returns the names declared by a top-level statement.
+func topLevelDeclarations(stmt *sitter.Node) set.Set[string] {
+	names := make(set.Set[string])
+	if stmt.Type() == "export_statement" {
+		stmt = stmt.ChildByFieldName("declaration")
+		if stmt == nil {
+			return names
+		}
+	}
+	switch stmt.Type() {
+	case "lexical_declaration", "variable_declaration":
+		for i := 0; i < int(stmt.NamedChildCount()); i++ {
+			decl := stmt.NamedChild(i)
+			if decl.Type() != "variable_declarator" {
+				continue
+			}
+			walkNodes(decl.ChildByFieldName("name"), func(n *sitter.Node) {
+				if isBinding(n) {
+					names.Add(n.Content())
+				}
+			})
+		}
+	case "function_declaration", "class_declaration":
+		names.Add(stmt.ChildByFieldName("name").Content())
+	}
+	return names
+}
+
+// bindings returns all the names bound anywhere within the node.
+func bindings(node *sitter.Node) set.Set[string] {
+	names := make(set.Set[string])
+	walkNodes(node, func(n *sitter.Node) {
+		if isBinding(n) {
+			names.Add(n.Content())
+		}
+	})
+	return names
+}
+
+func isBinding(n *sitter.Node) bool {
+	switch n.Type() {
+	case "shorthand_property_identifier_pattern":
+		return true
+	case "identifier":
+	default:
+		return false
+	}
+	parent := n.Parent()
+	if parent == nil {
+		return false
+	}
+	switch parent.Type() {
+	case "variable_declarator", "function_declaration", "function", "class_declaration":
+		return nodeIsField(parent, "name", n)
+	case "required_parameter", "optional_parameter":
+		return nodeIsField(parent, "pattern", n)
+	case "arrow_function":
+		return nodeIsField(parent, "parameter", n)
+	case "catch_clause":
+		return nodeIsField(parent, "parameter", n)
+	case "pair_pattern":
+		return nodeIsField(parent, "value", n)
+	case "array_pattern", "rest_pattern", "import_specifier", "namespace_import", "import_clause":
+		return true
+	}
+	return false
+}
+
+func isReference(n *sitter.Node) bool {
+	switch n.Type() {
+	case "shorthand_property_identifier":
+		return true
+	case "identifier":
+		return !isBinding(n)
+	}
+	return false
+}
+
+func nodeIsField(parent *sitter.Node, field string, n *sitter.Node) bool {
+	child := parent.ChildByFieldName(field)
+	return child != nil && child.Equal(n)
+}
+
+// typeNodes are the kinds of nodes which only describe types. Since types are erased at runtime,
+// they're skipped when walking a program.
+var typeNodes = set.SetOf(
+	"type_annotation", "type_arguments", "type_parameters", "nested_type_identifier", "generic_type",
+	"predefined_type", "type_identifier", "interface_declaration", "type_alias_declaration",
+)
+
+func walkNodes(node *sitter.Node, f func(*sitter.Node)) {
+	if node == nil || typeNodes.Contains(node.Type()) {
+		return
+	}
+	f(node)
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		walkNodes(node.NamedChild(i), f)
+	}
+}
+
+// specialArgs are the arguments provided to templates that do not come from the resource's properties.
+// Provider is set on kubernetes resources by [addPulumiKubernetesProviders] and on aws resources placed in a
+// non-default region or account by [addPulumiAwsProviders].
+var specialArgs = set.SetOf("Name", "dependsOn", "Provider")
+
+// VerifyTemplateArgs checks that every `args.X` referenced by a resource template is declared in its `Args`
+// interface and is a property of the resource in the knowledge base.
+func VerifyTemplateArgs(kb knowledgebase.TemplateKB, tmpl *ResourceTemplate, content []byte) error {
+	root, err := parseFile(bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	var id construct.ResourceId
+	if err := id.UnmarshalText([]byte(tmpl.Name)); err != nil {
+		return err
+	}
+	rt, err := kb.GetResourceTemplate(id)
+	if err != nil {
+		return fmt.Errorf("could not get knowledge base template for %s: %w", tmpl.Name, err)
+	}
+
+	used := make(set.Set[string])
+	walkNodes(root, func(n *sitter.Node) {
+		if n.Type() != "member_expression" {
+			return
+		}
+		obj, prop := n.ChildByFieldName("object"), n.ChildByFieldName("property")
+		if obj != nil && prop != nil && obj.Type() == "identifier" && obj.Content() == "args" {
+			used.Add(prop.Content())
+		}
+	})
+	names := used.ToSlice()
+	sort.Strings(names)
+
+	var errs error
+	for _, name := range names {
+		if _, ok := tmpl.Args[name]; !ok {
+			errs = errors.Join(errs, fmt.Errorf("%s: args.%s is not declared in Args", tmpl.Name, name))
+			continue
+		}
+		if specialArgs.Contains(name) {
+			continue
+		}
+		if _, ok := globalVariables[name]; ok {
+			continue
+		}
+		if rt.GetProperty(name) == nil {
+			errs = errors.Join(errs, fmt.Errorf("%s: args.%s is not a property of %s", tmpl.Name, name, rt.QualifiedTypeName))
+		}
+	}
+	return errs
+}
+
+// Verify checks the rendered program and all the templates used to render it.
+func (tc *TemplatesCompiler) Verify(kb knowledgebase.TemplateKB, program []byte) error {
+	errs := VerifyProgram(program)
+
+	typeNames := make([]string, 0, len(tc.templates.resourceTemplates))
+	for name := range tc.templates.resourceTemplates {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	for _, name := range typeNames {
+		tmpl := tc.templates.resourceTemplates[name]
+		var id construct.ResourceId
+		if err := id.UnmarshalText([]byte(name)); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if _, err := kb.GetResourceTemplate(id); err != nil {
+			// Resources added during translation (such as kubernetes providers) have no knowledge base template
+			continue
+		}
+		content, err := fs.ReadFile(tc.templates.fs, tmpl.Path+"/factory.ts")
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		errs = errors.Join(errs, VerifyTemplateArgs(kb, tmpl, content))
+	}
+	return errs
+}
@@ -0,0 +1,113 @@
+package iac3
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"testing"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	"github.com/klothoplatform/klotho/pkg/knowledge_base2/reader"
+	"github.com/klothoplatform/klotho/pkg/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyProgram(t *testing.T) {
+	tests := []struct {
+		name    string
+		program string
+		want    []ReferenceError
+	}{
+		{
+			name: "valid program",
+			program: `import * as aws from '@pulumi/aws'
+import { OutputInstance } from '@pulumi/pulumi'
+
+const vpc = new aws.ec2.Vpc("vpc", {})
+const subnets = [1, 2].map((i) => new aws.ec2.Subnet(` + "`subnet-${i}`" + `, { vpcId: vpc.id }))
+const lb = new aws.lb.LoadBalancer("lb", { subnets: subnets.map((s) => s.id) })
+const uri = (lb as aws.lb.LoadBalancer).dnsName
+export const lb_DomainName = lb.dnsName
+`,
+		},
+		{
+			name: "undeclared variable",
+			program: `import * as aws from '@pulumi/aws'
+
+const subnet = new aws.ec2.Subnet("subnet", { vpcId: vpc.id })
+`,
+			want: []ReferenceError{{Name: "vpc", Line: 3, Column: 54}},
+		},
+		{
+			name: "used before declared",
+			program: `import * as aws from '@pulumi/aws'
+
+const subnet = new aws.ec2.Subnet("subnet", { vpcId: vpc.id })
+const vpc = new aws.ec2.Vpc("vpc", {})
+`,
+			want: []ReferenceError{{Name: "vpc", Line: 3, Column: 54, UsedBeforeDeclared: true}},
+		},
+		{
+			name: "self reference",
+			program: `import * as aws from '@pulumi/aws'
+
+const vpc = new aws.ec2.Vpc("vpc", {}, { dependsOn: [vpc] })
+`,
+			want: []ReferenceError{{Name: "vpc", Line: 3, Column: 54, UsedBeforeDeclared: true}},
+		},
+		{
+			name: "missing import",
+			program: `const vpc = new aws.ec2.Vpc("vpc", { tags })
+`,
+			want: []ReferenceError{{Name: "aws", Line: 1, Column: 17}, {Name: "tags", Line: 1, Column: 38}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyProgram([]byte(tt.program))
+			if len(tt.want) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var got []ReferenceError
+			if joined, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, e := range joined.Unwrap() {
+					var refErr ReferenceError
+					if errors.As(e, &refErr) {
+						got = append(got, refErr)
+					}
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestTemplateArgs verifies that every template only uses arguments which are properties in the knowledge base.
+func TestTemplateArgs(t *testing.T) {
+	kb, err := reader.NewKBFromFs(templates.ResourceTemplates, templates.EdgeTemplates, templates.Models)
+	require.NoError(t, err)
+
+	templatesFS, err := fs.Sub(standardTemplates, "templates")
+	require.NoError(t, err)
+	factories, err := fs.Glob(templatesFS, "*/*/factory.ts")
+	require.NoError(t, err)
+
+	tc := &TemplatesCompiler{templates: &templateStore{fs: templatesFS}}
+	for _, factory := range factories {
+		dir := path.Dir(factory)
+		id := construct.ResourceId{Provider: path.Dir(dir), Type: path.Base(dir)}
+		if _, err := kb.GetResourceTemplate(id); err != nil {
+			continue
+		}
+		t.Run(strings.TrimSuffix(factory, "/factory.ts"), func(t *testing.T) {
+			tmpl, err := tc.ResourceTemplate(id)
+			require.NoError(t, err)
+			content, err := fs.ReadFile(templatesFS, factory)
+			require.NoError(t, err)
+			assert.NoError(t, VerifyTemplateArgs(kb, tmpl, content))
+		})
+	}
+}
@@ -60,12 +60,14 @@ const (
 
 func NewKB() *KnowledgeBase {
 	return &KnowledgeBase{
-		underlying: graph.New[string, *ResourceTemplate](func(t *ResourceTemplate) string {
-			return t.Id().QualifiedTypeName()
-		}, graph.Directed()),
+		underlying: graph.New(resourceTemplateHash, graph.Directed()),
 	}
 }
 
+func resourceTemplateHash(t *ResourceTemplate) string {
+	return t.Id().QualifiedTypeName()
+}
+
 func (kb *KnowledgeBase) GetModel(model string) *Model {
 	return kb.Models[model]
 }
@@ -207,7 +209,11 @@ func (kb *KnowledgeBase) HasFunctionalPath(from, to construct.ResourceId) bool {
 }
 
 func (kb *KnowledgeBase) AllPaths(from, to construct.ResourceId) ([][]*ResourceTemplate, error) {
-	paths, err := graph.AllPathsBetween(kb.underlying, from.QualifiedTypeName(), to.QualifiedTypeName())
+	pathGraph, err := kb.pathGraph(from.QualifiedTypeName(), to.QualifiedTypeName())
+	if err != nil {
+		return nil, err
+	}
+	paths, err := graph.AllPathsBetween(pathGraph, from.QualifiedTypeName(), to.QualifiedTypeName())
 	if err != nil {
 		return nil, err
 	}
@@ -221,6 +227,44 @@ func (kb *KnowledgeBase) AllPaths(from, to construct.ResourceId) ([][]*ResourceT
 	return resources, nil
 }
 
+// pathGraph returns a copy of the knowledge base graph for finding paths from one type to another. Edges marked as
+// direct edge only are left out, other than the edge directly between from and to, since they cannot be used within
+// the paths of other edges.
+func (kb *KnowledgeBase) pathGraph(from, to string) (graph.Graph[string, *ResourceTemplate], error) {
+	g := graph.New(resourceTemplateHash, graph.Directed())
+	vertices, err := kb.underlying.AdjacencyMap()
+	if err != nil {
+		return nil, err
+	}
+	for id := range vertices {
+		template, err := kb.underlying.Vertex(id)
+		if err != nil {
+			return nil, err
+		}
+		if err := g.AddVertex(template); err != nil {
+			return nil, err
+		}
+	}
+	for _, adj := range vertices {
+		for _, edge := range adj {
+			template, ok := edge.Properties.Data.(*EdgeTemplate)
+			if ok && template.DirectEdgeOnly && (edge.Source != from || edge.Target != to) {
+				continue
+			}
+			err := g.AddEdge(
+				edge.Source,
+				edge.Target,
+				graph.EdgeData(edge.Properties.Data),
+				graph.EdgeWeight(edge.Properties.Weight),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return g, nil
+}
+
 func (kb *KnowledgeBase) GetAllowedNamespacedResourceIds(ctx DynamicValueContext, resourceId construct.ResourceId) ([]construct.ResourceId, error) {
 
 	template, err := kb.GetResourceTemplate(resourceId)
@@ -0,0 +1,78 @@
+package knowledgebase2
+
+import (
+	"testing"
+
+	construct "github.com/klothoplatform/klotho/pkg/construct2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnowledgeBase_AllPaths(t *testing.T) {
+	a := construct.ResourceId{Provider: "p", Type: "a"}
+	b := construct.ResourceId{Provider: "p", Type: "b"}
+	c := construct.ResourceId{Provider: "p", Type: "c"}
+
+	tests := []struct {
+		name     string
+		edges    []*EdgeTemplate
+		from, to construct.ResourceId
+		want     [][]string
+	}{
+		{
+			name: "all paths",
+			edges: []*EdgeTemplate{
+				{Source: a, Target: b},
+				{Source: b, Target: c},
+				{Source: a, Target: c},
+			},
+			from: a,
+			to:   c,
+			want: [][]string{{"p:a", "p:b", "p:c"}, {"p:a", "p:c"}},
+		},
+		{
+			name: "direct edge only is not used within a path",
+			edges: []*EdgeTemplate{
+				{Source: a, Target: b},
+				{Source: b, Target: c, DirectEdgeOnly: true},
+				{Source: a, Target: c},
+			},
+			from: a,
+			to:   c,
+			want: [][]string{{"p:a", "p:c"}},
+		},
+		{
+			name: "direct edge only between from and to",
+			edges: []*EdgeTemplate{
+				{Source: a, Target: b},
+				{Source: b, Target: c},
+				{Source: a, Target: c, DirectEdgeOnly: true},
+			},
+			from: a,
+			to:   c,
+			want: [][]string{{"p:a", "p:b", "p:c"}, {"p:a", "p:c"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kb := NewKB()
+			for _, id := range []construct.ResourceId{a, b, c} {
+				require.NoError(t, kb.AddResourceTemplate(&ResourceTemplate{QualifiedTypeName: id.QualifiedTypeName()}))
+			}
+			for _, edge := range tt.edges {
+				require.NoError(t, kb.AddEdgeTemplate(edge))
+			}
+
+			paths, err := kb.AllPaths(tt.from, tt.to)
+			require.NoError(t, err)
+
+			got := make([][]string, len(paths))
+			for i, path := range paths {
+				for _, res := range path {
+					got[i] = append(got[i], res.QualifiedTypeName)
+				}
+			}
+			assert.ElementsMatch(t, tt.want, got)
+		})
+	}
+}
@@ -98,8 +98,14 @@ func (f *FloatProperty) Validate(resource *construct.Resource, value any, ctx kn
 		}
 		return nil
 	}
-	floatVal, ok := value.(float64)
-	if !ok {
+	var floatVal float64
+	switch val := value.(type) {
+	case float64:
+		floatVal = val
+	case float32:
+		// templated values are decoded as float32 by Parse
+		floatVal = float64(val)
+	default:
 		return fmt.Errorf("invalid float value %v", value)
 	}
 	if f.MinValue != nil && floatVal < *f.MinValue {
@@ -214,6 +214,15 @@ func Test_FloatProperty_Validate(t *testing.T) {
 			},
 			value: 1.0,
 		},
+		{
+			name: "parsed template value",
+			property: &FloatProperty{
+				PropertyDetails: knowledgebase.PropertyDetails{
+					Path: "test",
+				},
+			},
+			value: float32(1.0),
+		},
 		{
 			name: "int value",
 			property: &FloatProperty{
@@ -53,6 +53,9 @@ func (i *IntProperty) GetDefaultValue(ctx knowledgebase.DynamicContext, data kno
 	if i.DefaultValue == nil {
 		return nil, nil
 	}
+	if unset, err := i.defaultIsUnset(ctx, data); unset || err != nil {
+		return nil, err
+	}
 	return i.Parse(i.DefaultValue, ctx, data)
 }
 
@@ -343,6 +343,15 @@ func Test_IntProperty_GetDefaultValue(t *testing.T) {
 			},
 			expect: 1,
 		},
+		{
+			name: "conditional default value with template",
+			property: &IntProperty{
+				SharedPropertyFields: SharedPropertyFields{
+					DefaultValue: "{{ if false }}1{{ end }}",
+				},
+			},
+			expect: nil,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
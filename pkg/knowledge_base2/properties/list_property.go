@@ -113,7 +113,10 @@ func (list *ListProperty) Parse(value any, ctx knowledgebase.DynamicContext, dat
 		if strVal, ok := value.(string); ok {
 			var result []any
 			err := ctx.ExecuteDecode(strVal, data, &result)
-			return result, err
+			if err != nil {
+				return result, err
+			}
+			return list.parseTemplatedResources(result, ctx, data)
 		}
 		return nil, fmt.Errorf("invalid list value %v", value)
 	}
@@ -137,6 +140,46 @@ func (list *ListProperty) Parse(value any, ctx knowledgebase.DynamicContext, dat
 	return result, nil
 }
 
+// parseTemplatedResources parses the resources in a list decoded from a template, either as its items or as properties
+// of its maps, since the template can only render their ids as strings. Other values are left as they were decoded.
+func (list *ListProperty) parseTemplatedResources(
+	items []any,
+	ctx knowledgebase.DynamicContext,
+	data knowledgebase.DynamicValueData,
+) ([]any, error) {
+	if _, ok := list.ItemProperty.(*ResourceProperty); ok {
+		for i, item := range items {
+			id, err := list.ItemProperty.Parse(item, ctx, data)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = id
+		}
+		return items, nil
+	}
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		for name, prop := range list.Properties {
+			if _, ok := prop.(*ResourceProperty); !ok {
+				continue
+			}
+			v, ok := m[name]
+			if !ok {
+				continue
+			}
+			id, err := prop.Parse(v, ctx, data)
+			if err != nil {
+				return nil, err
+			}
+			m[name] = id
+		}
+	}
+	return items, nil
+}
+
 func (l *ListProperty) ZeroValue() any {
 	return nil
 }
@@ -269,6 +269,41 @@ func Test_ListProperty_Parse(t *testing.T) {
 			value:  []any{"{{ \"test\" }}"},
 			expect: []any{"test"},
 		},
+		{
+			name: "list property with templated list of resources",
+			property: &ListProperty{
+				PropertyDetails: knowledgebase.PropertyDetails{
+					Path: "test",
+				},
+				ItemProperty: &ResourceProperty{},
+			},
+			value: `["test:res:a", "test:res:b"]`,
+			expect: []any{
+				construct.ResourceId{Provider: "test", Type: "res", Name: "a"},
+				construct.ResourceId{Provider: "test", Type: "res", Name: "b"},
+			},
+		},
+		{
+			name: "list property with templated list of maps",
+			property: &ListProperty{
+				PropertyDetails: knowledgebase.PropertyDetails{
+					Path: "test",
+				},
+				Properties: knowledgebase.Properties{
+					"Port": &IntProperty{
+						PropertyDetails: knowledgebase.PropertyDetails{Path: "Port"},
+					},
+					"Target": &ResourceProperty{
+						PropertyDetails: knowledgebase.PropertyDetails{Path: "Target"},
+					},
+				},
+			},
+			value: `[{{ range $i, $c := split "a,b" "," }}{{ if $i }},{{ end }}{"Port": "80", "Target": "test:res:{{ $c }}"}{{ end }}]`,
+			expect: []any{
+				map[string]any{"Port": "80", "Target": construct.ResourceId{Provider: "test", Type: "res", Name: "a"}},
+				map[string]any{"Port": "80", "Target": construct.ResourceId{Provider: "test", Type: "res", Name: "b"}},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
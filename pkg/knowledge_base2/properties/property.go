@@ -24,6 +24,18 @@ type (
 	}
 )
 
+// defaultIsUnset returns whether the default value is a template which renders empty. This lets a default only apply
+// conditionally, leaving the property unset otherwise.
+func (s SharedPropertyFields) defaultIsUnset(ctx knowledgebase.DynamicContext, data knowledgebase.DynamicValueData) (bool, error) {
+	tmpl, ok := s.DefaultValue.(string)
+	if !ok {
+		return false, nil
+	}
+	var result string
+	err := ctx.ExecuteDecode(tmpl, data, &result)
+	return result == "", err
+}
+
 func ParsePropertyRef(value any, ctx knowledgebase.DynamicContext, data knowledgebase.DynamicValueData) (construct.PropertyRef, error) {
 	if val, ok := value.(string); ok {
 		result := construct.PropertyRef{}
@@ -55,6 +55,9 @@ func (s *StringProperty) GetDefaultValue(ctx knowledgebase.DynamicContext, data
 	if s.DefaultValue == nil {
 		return nil, nil
 	}
+	if unset, err := s.defaultIsUnset(ctx, data); unset || err != nil {
+		return nil, err
+	}
 	return s.Parse(s.DefaultValue, ctx, data)
 }
 
@@ -191,6 +191,15 @@ func Test_StringGetDefaultValue(t *testing.T) {
 			},
 			value: "test",
 		},
+		{
+			name: "conditional default renders empty",
+			property: &StringProperty{
+				SharedPropertyFields: SharedPropertyFields{
+					DefaultValue: "{{ if false }}test{{ end }}",
+				},
+			},
+			value: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
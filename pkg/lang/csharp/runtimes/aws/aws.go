@@ -52,7 +52,7 @@ var lambdaApiTypeClasses = map[string]qualifiedName{
 }
 
 //go:embed Lambda_Dockerfile.tmpl
-var dockerfileLambda []byte
+var DockerfileLambda []byte
 
 //go:embed Lambda_Dispatcher.cs.tmpl
 var dispatcherLambda []byte
@@ -76,7 +76,7 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 	unitType := r.Cfg.GetResourceType(unit)
 	switch unitType {
 	case "lambda":
-		dockerFile = dockerfileLambda
+		dockerFile = DockerfileLambda
 	default:
 		return errors.Errorf("unsupported execution unit type: '%s'", unitType)
 	}
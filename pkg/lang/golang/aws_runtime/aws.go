@@ -33,19 +33,19 @@ type (
 )
 
 //go:embed Lambda_Dockerfile
-var dockerfileLambda []byte
+var DockerfileLambda []byte
 
 //go:embed Exec_Dockerfile
-var dockerfileExec []byte
+var DockerfileExec []byte
 
 func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGraph *construct.ConstructGraph) error {
 	var DockerFile []byte
 	unitType := r.Cfg.GetResourceType(unit)
 	switch unitType {
 	case aws.Lambda:
-		DockerFile = dockerfileLambda
+		DockerFile = DockerfileLambda
 	case aws.Ecs, kubernetes.DEPLOYMENT_TYPE:
-		DockerFile = dockerfileExec
+		DockerFile = DockerfileExec
 	default:
 		return errors.Errorf("unsupported execution unit type: '%s'", unitType)
 	}
@@ -89,10 +89,10 @@ var dispatcherLambda []byte
 var dispatcherFargate []byte
 
 //go:embed Lambda_Dockerfile.tmpl
-var dockerfileLambda []byte
+var DockerfileLambda []byte
 
 //go:embed Fargate_Dockerfile.tmpl
-var dockerfileFargate []byte
+var DockerfileFargate []byte
 
 var sequelizeReplaceRE = regexp.MustCompile(`new (\w+\.|\b)Sequelize\(`)
 
@@ -227,10 +227,10 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 	unitType := r.Config.GetResourceType(unit)
 	switch unitType {
 	case aws.Ecs, kubernetes.DEPLOYMENT_TYPE, aws.AppRunner:
-		DockerFile = dockerfileFargate
+		DockerFile = DockerfileFargate
 		Dispatcher = dispatcherFargate
 	case aws.Lambda:
-		DockerFile = dockerfileLambda
+		DockerFile = DockerfileLambda
 		Dispatcher = dispatcherLambda
 
 		unit.EnvironmentVariables.Add(types.InternalStorageVariable)
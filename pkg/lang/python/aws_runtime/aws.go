@@ -20,10 +20,10 @@ import (
 //go:generate ./compile_template.sh dispatcher_fargate dispatcher_lambda fs secret
 
 //go:embed Fargate_Dockerfile.tmpl
-var dockerfileFargate []byte
+var DockerfileFargate []byte
 
 //go:embed Lambda_Dockerfile.tmpl
-var dockerfileLambda []byte
+var DockerfileLambda []byte
 
 //go:embed dispatcher_fargate.py.tmpl
 var dispatcherFargate []byte
@@ -97,7 +97,7 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 	unitType := r.Cfg.GetResourceType(unit)
 	switch unitType {
 	case aws.Lambda:
-		dockerFile = dockerfileLambda
+		dockerFile = DockerfileLambda
 		dispatcher = dispatcherLambda
 		requirements = execRequirementsLambda
 
@@ -108,7 +108,7 @@ func (r *AwsRuntime) AddExecRuntimeFiles(unit *types.ExecutionUnit, constructGra
 			return err
 		}
 	case aws.Ecs, kubernetes.DEPLOYMENT_TYPE, aws.AppRunner, aws.Ec2Instance:
-		dockerFile = dockerfileFargate
+		dockerFile = DockerfileFargate
 		dispatcher = dispatcherFargate
 		requirements = execRequirementsFargate
 	default:
@@ -1,10 +1,33 @@
+// Package updater updates the klotho binary in place, from the update server or a local mirror of it.
+//
+// A release may be verified against a manifest signed with the release signing key. Verification is opt in (see
+// [Updater.Verify]) until the update server publishes signed manifests; without it, an update trusts the version
+// reported by the server and applies the binary as downloaded. A verified release publishes two files next to the
+// binary:
+//
+//   - manifest.json, a JSON [Manifest] with the release's version, OS, architecture and the hex-encoded SHA-256
+//     checksum of the binary.
+//   - manifest.json.sig, the base64-encoded Ed25519 signature of the exact bytes of manifest.json.
+//
+// The server serves them at `/update/latest/<os>/<arch>/manifest.json` and `/update/latest/<os>/<arch>/manifest.json.sig`
+// (with the same `stream` query parameter as the binary), and a mirror at `<qualifier>/<tag>/<os>/<arch>/` for
+// stream `<qualifier>:<tag>`. Signatures are checked against [ReleaseSigningKey], which is embedded from
+// release_signing_key.pub (the base64-encoded public key), so the release pipeline that signs manifests must hold the
+// matching private key.
 package updater
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	_ "embed"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 
@@ -26,55 +49,99 @@ const (
 	DefaultServer string = "http://srv.klo.dev"
 )
 
-type Updater struct {
-	ServerURL string
-	// Stream is the update stream to check
-	Stream string
-	// CurrentStream is the stream this binary came from
-	CurrentStream string
+const (
+	manifestFile  = "manifest.json"
+	signatureFile = "manifest.json.sig"
+	binaryFile    = "klotho"
+
+	// maxManifestSize limits how much is read for the manifest and signature, which are both small
+	maxManifestSize = 1 << 20
+)
+
+var (
+	//go:embed release_signing_key.pub
+	releaseSigningKeyFile string
+
+	// ReleaseSigningKey is the public key release manifests are signed with
+	ReleaseSigningKey = mustParsePublicKey(releaseSigningKeyFile)
+)
+
+type (
+	Updater struct {
+		ServerURL string
+		// Stream is the update stream to check
+		Stream string
+		// CurrentStream is the stream this binary came from
+		CurrentStream string
+		// MirrorDir, if set, is a local directory to read releases from instead of the server. Releases are laid out as
+		// `<MirrorDir>/<qualifier>/<tag>/<os>/<arch>/{manifest.json,manifest.json.sig,klotho}`.
+		MirrorDir string
+		// AllowDowngrade permits updating to a version older than the current one
+		AllowDowngrade bool
+		// Verify requires the release manifest to be signed, and the binary to match its checksum. Mirrors always
+		// provide a manifest, so their binary is checked against it even if the manifest is not verified.
+		Verify bool
+		// PublicKey verifies the signature of release manifests. Defaults to [ReleaseSigningKey].
+		PublicKey ed25519.PublicKey
+		// TargetPath is the binary to replace. Defaults to the running executable.
+		TargetPath string
+
+		Client *httpclient.Client
+	}
+
+	// Manifest describes a release binary. The manifest is signed so that the binary's checksum can be trusted.
+	Manifest struct {
+		Version string `json:"version"`
+		OS      string `json:"os"`
+		Arch    string `json:"arch"`
+		// SHA256 is the hex-encoded SHA-256 checksum of the binary
+		SHA256 string `json:"sha256"`
+	}
+)
 
-	Client *httpclient.Client
+func mustParsePublicKey(encoded string) ed25519.PublicKey {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		panic(fmt.Errorf("invalid release signing key: %w", err))
+	}
+	if len(key) != ed25519.PublicKeySize {
+		panic(fmt.Errorf("invalid release signing key: expected %d bytes, got %d", ed25519.PublicKeySize, len(key)))
+	}
+	return ed25519.PublicKey(key)
 }
 
-func selfUpdate(data io.Reader) error {
-	//TODO add signature verification if we want
-	return update.Apply(data, update.Options{})
+// selfUpdate replaces the binary at targetPath (or the running executable if empty) with data. The update is only
+// applied if data matches the checksum.
+func selfUpdate(data io.Reader, checksum []byte, targetPath string) error {
+	return update.Apply(data, update.Options{
+		TargetPath: targetPath,
+		Checksum:   checksum,
+	})
 }
 
 // CheckUpdate compares the version of the klotho binary
 // against the latest github release, returns true
 // if the latest release is newer
 func (u *Updater) CheckUpdate(currentVersion string) (bool, error) {
-	endpoint := fmt.Sprintf("%s/update/check-latest-version?stream=%s", u.ServerURL, u.Stream)
-	res, err := u.Client.Get(endpoint, nil)
-	if err != nil {
-		return false, fmt.Errorf("failed to query for latest version: %v", err)
-	}
-	if res.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("failed to query for latest version, bad response from server: %d", res.StatusCode)
-
-	}
-	defer res.Body.Close()
-
-	result := make(map[string]string)
-	dec := json.NewDecoder(res.Body)
-	if err := dec.Decode(&result); err != nil {
-		return false, fmt.Errorf("failed to decode body: %v", err)
-	}
+	doUpdate, _, err := u.checkUpdate(currentVersion)
+	return doUpdate, err
+}
 
-	ver, ok := result["latest_version"]
-	if !ok {
-		return false, errors.New("no version found in result")
+// checkUpdate is [Updater.CheckUpdate], also returning the latest version.
+func (u *Updater) checkUpdate(currentVersion string) (bool, string, error) {
+	ver, err := u.latestVersion()
+	if err != nil {
+		return false, "", err
 	}
 
 	latestVersion, err := semver.NewVersion(ver)
 	if err != nil {
-		return false, fmt.Errorf("strange version received: %s", latestVersion)
+		return false, "", fmt.Errorf("strange version received: %s", latestVersion)
 	}
 
 	currVersion, err := semver.NewVersion(strings.TrimPrefix(currentVersion, "v"))
 	if err != nil {
-		return false, fmt.Errorf("invalid version %s: %v", currentVersion, err)
+		return false, "", fmt.Errorf("invalid version %s: %v", currentVersion, err)
 	}
 
 	// Given a stream "xxx:yyyy", the qualifier is the "xxx" and the tag is the "yyyy".
@@ -95,21 +162,56 @@ func (u *Updater) CheckUpdate(currentVersion string) (bool, error) {
 
 	// case (1): different qualifiers always update
 	if strings.Split(u.CurrentStream, ":")[0] != strings.Split(u.Stream, ":")[0] {
-		return true, nil
+		return true, ver, nil
 	}
 
 	// the qualifiers are the same, so the tags are the same iff the full stream strings are the same
 	if u.CurrentStream == u.Stream {
-		return currVersion.LessThan(*latestVersion), nil // case (2a): only upgrades
+		return currVersion.LessThan(*latestVersion), ver, nil // case (2a): only upgrades
 	} else {
-		return !currVersion.Equal(*latestVersion), nil // case (2b): upgrades or downgrades
+		return !currVersion.Equal(*latestVersion), ver, nil // case (2b): upgrades or downgrades
 	}
 }
 
+// latestVersion returns the latest version on the stream. When reading from a mirror, this is the version in the
+// manifest.
+func (u *Updater) latestVersion() (string, error) {
+	if u.MirrorDir != "" {
+		manifest, err := u.getManifest()
+		if err != nil {
+			return "", err
+		}
+		return manifest.Version, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/update/check-latest-version?stream=%s", u.ServerURL, u.Stream)
+	res, err := u.Client.Get(endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to query for latest version: %v", err)
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to query for latest version, bad response from server: %d", res.StatusCode)
+
+	}
+	defer res.Body.Close()
+
+	result := make(map[string]string)
+	dec := json.NewDecoder(res.Body)
+	if err := dec.Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode body: %v", err)
+	}
+
+	ver, ok := result["latest_version"]
+	if !ok {
+		return "", errors.New("no version found in result")
+	}
+	return ver, nil
+}
+
 // Update performs an update if a newer version is
 // available
 func (u *Updater) Update(currentVersion string) error {
-	doUpdate, err := u.CheckUpdate(currentVersion)
+	doUpdate, latestVersion, err := u.checkUpdate(currentVersion)
 	if err != nil {
 		zap.S().Errorf(`error checking for updates on stream "%s": %v`, u.Stream, err)
 		return err
@@ -120,46 +222,155 @@ func (u *Updater) Update(currentVersion string) error {
 		return nil
 	}
 
-	resp, err := u.getLatest()
+	// checksum is left empty for unverified server releases, which have no manifest
+	var checksum []byte
+	if u.Verify || u.MirrorDir != "" {
+		manifest, err := u.getManifest()
+		if err != nil {
+			return errors.Wrapf(err, "failed to verify latest release")
+		}
+		latestVersion = manifest.Version
+		checksum, err = hex.DecodeString(manifest.SHA256)
+		if err != nil {
+			return fmt.Errorf("invalid checksum in release manifest: %v", err)
+		}
+	}
+	if err := u.checkDowngrade(currentVersion, latestVersion); err != nil {
+		return err
+	}
+
+	body, size, err := u.open(binaryFile)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get latest")
 	}
-	if resp.Body == nil {
-		return errors.New("No response body from download")
-	}
-	defer resp.Body.Close()
+	defer body.Close()
 
-	var body io.Reader = resp.Body
+	var download io.Reader = body
 	if !color.NoColor {
 		// Use NoColor as an indicator of whether the output
 		// is a terminal or not. It's not perfect (the env var "NO_COLOR")
 		// but it's close enough.
 		bar := progressbar.DefaultBytes(
-			resp.ContentLength,
+			size,
 			"downloading",
 		)
-		teeToBar := progressbar.NewReader(body, bar)
-		body = &teeToBar
+		teeToBar := progressbar.NewReader(download, bar)
+		download = &teeToBar
 	}
 
-	if err := selfUpdate(body); err != nil {
+	if err := selfUpdate(download, checksum, u.TargetPath); err != nil {
 		return errors.Wrapf(err, "failed to update klotho")
 	}
-	zap.S().Infof(`updated to the latest version on stream "%s"`, u.Stream)
+	zap.S().Infof(`updated to version %s on stream "%s"`, latestVersion, u.Stream)
 	return nil
 }
 
-// getLatest Grabs latest release from klotho server
-func (u *Updater) getLatest() (*http.Response, error) {
-	endpoint := fmt.Sprintf("%s/update/latest/%s/%s?stream=%s", u.ServerURL, OS, Arch, u.Stream)
-	res, err := u.Client.Get(endpoint, nil)
+// checkDowngrade returns an error if going from currentVersion to newVersion is a downgrade and downgrades are not
+// allowed.
+func (u *Updater) checkDowngrade(currentVersion, newVersion string) error {
+	if u.AllowDowngrade {
+		return nil
+	}
+	currVersion, err := semver.NewVersion(strings.TrimPrefix(currentVersion, "v"))
 	if err != nil {
-		return nil, fmt.Errorf("failed to query for latest version: %v", err)
+		return fmt.Errorf("invalid version %s: %v", currentVersion, err)
 	}
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to query for latest version, bad response from server: %d", res.StatusCode)
+	nextVersion, err := semver.NewVersion(strings.TrimPrefix(newVersion, "v"))
+	if err != nil {
+		return fmt.Errorf("invalid version in release manifest %s: %v", newVersion, err)
+	}
+	if nextVersion.LessThan(*currVersion) {
+		return fmt.Errorf("refusing to downgrade from %s to %s without explicitly allowing downgrades", currVersion, nextVersion)
+	}
+	return nil
+}
 
+// getManifest fetches the release manifest and verifies that it is for this platform and, if [Updater.Verify] is set,
+// its signature.
+func (u *Updater) getManifest() (*Manifest, error) {
+	content, err := u.readAll(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	if u.Verify {
+		if err := u.verifyManifest(content); err != nil {
+			return nil, err
+		}
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %v", err)
+	}
+	if manifest.OS != OS || manifest.Arch != Arch {
+		return nil, fmt.Errorf("manifest is for %s/%s, expected %s/%s", manifest.OS, manifest.Arch, OS, Arch)
 	}
-	return res, nil
+	return &manifest, nil
+}
 
+// verifyManifest checks the manifest's signature against the release signing key (or [Updater.PublicKey]).
+func (u *Updater) verifyManifest(content []byte) error {
+	sigContent, err := u.readAll(signatureFile)
+	if err != nil {
+		return err
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(sigContent)))
+	if err != nil {
+		return fmt.Errorf("invalid manifest signature encoding: %v", err)
+	}
+
+	key := u.PublicKey
+	if key == nil {
+		key = ReleaseSigningKey
+	}
+	if !ed25519.Verify(key, content, signature) {
+		return errors.New("manifest signature verification failed")
+	}
+	return nil
+}
+
+func (u *Updater) readAll(file string) ([]byte, error) {
+	r, _, err := u.open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(io.LimitReader(r, maxManifestSize))
+}
+
+// open opens one of the files of the latest release, either from the mirror directory or the server. It also returns
+// the size of the file, or -1 if unknown.
+func (u *Updater) open(file string) (io.ReadCloser, int64, error) {
+	if u.MirrorDir != "" {
+		parts := append([]string{u.MirrorDir}, strings.Split(u.Stream, ":")...)
+		parts = append(parts, OS, Arch, file)
+		f, err := os.Open(filepath.Join(parts...))
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to read %s from mirror: %v", file, err)
+		}
+		info, err := f.Stat()
+		if err != nil {
+			f.Close()
+			return nil, 0, err
+		}
+		return f, info.Size(), nil
+	}
+
+	endpoint := fmt.Sprintf("%s/update/latest/%s/%s", u.ServerURL, OS, Arch)
+	if file != binaryFile {
+		endpoint += "/" + file
+	}
+	endpoint += "?stream=" + u.Stream
+	res, err := u.Client.Get(endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query for latest %s: %v", file, err)
+	}
+	if res.Body == nil {
+		return nil, 0, errors.New("No response body from download")
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, 0, fmt.Errorf("failed to query for latest %s, bad response from server: %d", file, res.StatusCode)
+	}
+	return res.Body, res.ContentLength, nil
 }
@@ -1,12 +1,21 @@
 package updater
 
 import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
-	"github.com/gojek/heimdall/v7/httpclient"
-	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/gojek/heimdall/v7/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCheckUpdate(t *testing.T) {
@@ -200,12 +209,222 @@ func TestCheckUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdate(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	newBinary := []byte("new klotho binary")
+	checksum := sha256.Sum256(newBinary)
+
+	type release struct {
+		version string
+		binary  []byte
+		// signWith is the key used to sign the manifest, defaults to privateKey
+		signWith ed25519.PrivateKey
+		// unsigned releases are published without a signature
+		unsigned bool
+	}
+	cases := []struct {
+		name           string
+		stream         string
+		currentVersion string
+		allowDowngrade bool
+		// unverified updates without verifying the manifest, as releases are until the server publishes signed manifests
+		unverified bool
+		release    release
+		// expectDownload is whether the binary should be requested
+		expectDownload bool
+		expectUpdate   bool
+		expectError    bool
+	}{
+		{
+			name:           `newer version`,
+			stream:         `open:latest`,
+			currentVersion: `0.5.0`,
+			release:        release{version: `0.5.1`, binary: newBinary},
+			expectDownload: true,
+			expectUpdate:   true,
+		},
+		{
+			name:           `manifest signed with wrong key`,
+			stream:         `open:latest`,
+			currentVersion: `0.5.0`,
+			release:        release{version: `0.5.1`, binary: newBinary, signWith: otherKey},
+			expectError:    true,
+		},
+		{
+			name:           `manifest not signed`,
+			stream:         `open:latest`,
+			currentVersion: `0.5.0`,
+			release:        release{version: `0.5.1`, binary: newBinary, unsigned: true},
+			expectError:    true,
+		},
+		{
+			name:           `manifest not signed, unverified`,
+			stream:         `open:latest`,
+			currentVersion: `0.5.0`,
+			unverified:     true,
+			release:        release{version: `0.5.1`, binary: newBinary, unsigned: true},
+			expectDownload: true,
+			expectUpdate:   true,
+		},
+		{
+			name:           `binary does not match checksum`,
+			stream:         `open:latest`,
+			currentVersion: `0.5.0`,
+			release:        release{version: `0.5.1`, binary: []byte("tampered binary")},
+			expectDownload: true,
+			expectError:    true,
+		},
+		{
+			name:           `pinned to older version`,
+			stream:         `open:v0.5.0`,
+			currentVersion: `0.5.1`,
+			release:        release{version: `0.5.0`, binary: newBinary},
+			expectError:    true,
+		},
+		{
+			name:           `pinned to older version, downgrade allowed`,
+			stream:         `open:v0.5.0`,
+			currentVersion: `0.5.1`,
+			allowDowngrade: true,
+			release:        release{version: `0.5.0`, binary: newBinary},
+			expectDownload: true,
+			expectUpdate:   true,
+		},
+		{
+			name:           `pinned to older version, unverified`,
+			stream:         `open:v0.5.0`,
+			currentVersion: `0.5.1`,
+			unverified:     true,
+			release:        release{version: `0.5.0`, binary: newBinary, unsigned: true},
+			expectError:    true,
+		},
+	}
+	for _, tt := range cases {
+		signWith := tt.release.signWith
+		if signWith == nil {
+			signWith = privateKey
+		}
+		manifest, err := json.Marshal(Manifest{
+			Version: tt.release.version,
+			OS:      OS,
+			Arch:    Arch,
+			SHA256:  hex.EncodeToString(checksum[:]),
+		})
+		require.NoError(t, err)
+		signature := []byte(base64.StdEncoding.EncodeToString(ed25519.Sign(signWith, manifest)))
+		signatureStatus := http.StatusOK
+		if tt.release.unsigned {
+			signature = []byte("not found")
+			signatureStatus = http.StatusNotFound
+		}
+
+		checkUpdate := func(t *testing.T, updater Updater, target string) {
+			err := updater.Update(tt.currentVersion)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			content, err := os.ReadFile(target)
+			require.NoError(t, err)
+			if tt.expectUpdate {
+				assert.Equal(t, newBinary, content)
+			} else {
+				assert.Equal(t, []byte("old klotho binary"), content)
+			}
+		}
+
+		t.Run(tt.name, func(t *testing.T) {
+			target := filepath.Join(t.TempDir(), "klotho")
+			require.NoError(t, os.WriteFile(target, []byte("old klotho binary"), 0755))
+
+			handler := interactions{assert: assert.New(t)}
+			latest := `/update/latest/` + OS + `/` + Arch
+			handler.interactions = append(handler.interactions,
+				requestResponse{
+					inUri:     `/update/check-latest-version?stream=` + tt.stream,
+					inMethod:  http.MethodGet,
+					outStatus: http.StatusOK,
+					outBody:   map[string]string{`latest_version`: tt.release.version},
+				},
+			)
+			if !tt.unverified {
+				handler.interactions = append(handler.interactions,
+					requestResponse{
+						inUri:     latest + `/manifest.json?stream=` + tt.stream,
+						inMethod:  http.MethodGet,
+						outStatus: http.StatusOK,
+						outBody:   manifest,
+					},
+					requestResponse{
+						inUri:     latest + `/manifest.json.sig?stream=` + tt.stream,
+						inMethod:  http.MethodGet,
+						outStatus: signatureStatus,
+						outBody:   signature,
+					},
+				)
+			}
+			if tt.expectDownload {
+				handler.interactions = append(handler.interactions, requestResponse{
+					inUri:     latest + `?stream=` + tt.stream,
+					inMethod:  http.MethodGet,
+					outStatus: http.StatusOK,
+					outBody:   tt.release.binary,
+				})
+			}
+			server := httptest.NewServer(&handler)
+			defer server.Close()
+
+			checkUpdate(t, Updater{
+				ServerURL:      server.URL,
+				Stream:         tt.stream,
+				CurrentStream:  `open:latest`,
+				AllowDowngrade: tt.allowDowngrade,
+				Verify:         !tt.unverified,
+				PublicKey:      publicKey,
+				TargetPath:     target,
+				Client:         httpclient.NewClient(),
+			}, target)
+			assert.Empty(t, handler.interactions, "didn't see all expected interactions")
+		})
+
+		t.Run(tt.name+` (mirror)`, func(t *testing.T) {
+			target := filepath.Join(t.TempDir(), "klotho")
+			require.NoError(t, os.WriteFile(target, []byte("old klotho binary"), 0755))
+
+			mirror := t.TempDir()
+			releaseDir := filepath.Join(mirror, strings.ReplaceAll(tt.stream, ":", "/"), OS, Arch)
+			require.NoError(t, os.MkdirAll(releaseDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(releaseDir, "manifest.json"), manifest, 0644))
+			if !tt.release.unsigned {
+				require.NoError(t, os.WriteFile(filepath.Join(releaseDir, "manifest.json.sig"), signature, 0644))
+			}
+			require.NoError(t, os.WriteFile(filepath.Join(releaseDir, "klotho"), tt.release.binary, 0644))
+
+			checkUpdate(t, Updater{
+				MirrorDir:      mirror,
+				Stream:         tt.stream,
+				CurrentStream:  `open:latest`,
+				AllowDowngrade: tt.allowDowngrade,
+				Verify:         !tt.unverified,
+				PublicKey:      publicKey,
+				TargetPath:     target,
+			}, target)
+		})
+	}
+}
+
 type (
 	requestResponse struct {
 		inMethod  string
 		inUri     string
 		outStatus int
-		outBody   any
+		// outBody is written as-is if it is a []byte, otherwise it is marshalled as JSON
+		outBody any
 	}
 
 	interactions struct {
@@ -224,13 +443,17 @@ func (s *interactions) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	s.interactions = s.interactions[1:]
 
 	if s.assert.Equal(curr.inMethod, r.Method) && s.assert.Equal(curr.inUri, r.URL.RequestURI()) {
-		body, err := json.Marshal(curr.outBody)
-		if !s.assert.NoError(err) {
-			w.WriteHeader(http.StatusInternalServerError)
-			return
+		body, isRaw := curr.outBody.([]byte)
+		if !isRaw {
+			var err error
+			body, err = json.Marshal(curr.outBody)
+			if !s.assert.NoError(err) {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
 		}
 		w.WriteHeader(curr.outStatus)
-		_, err = w.Write(body)
+		_, err := w.Write(body)
 		s.assert.NoError(err)
 	} else {
 		s.assert.Fail("no interactions left")